@@ -0,0 +1,253 @@
+package timber
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// OverflowPolicy is what an AsyncWriter will do with a new entry when its
+// queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowPolicy_Block will wait for the background goroutine to make room
+	// in the queue, nothing is dropped.
+	OverflowPolicy_Block OverflowPolicy = iota
+
+	// OverflowPolicy_DropNewest will drop the entry that is being written.
+	OverflowPolicy_DropNewest
+
+	// OverflowPolicy_DropOldest will drop the oldest entry in the queue to make
+	// room for the entry that is being written.
+	OverflowPolicy_DropOldest
+
+	// OverflowPolicy_DropBelowLevel will drop the entry that is being written
+	// if its level is below AsyncOptions.DropLevel, otherwise it will wait for
+	// room in the queue the same way OverflowPolicy_Block does.
+	OverflowPolicy_DropBelowLevel
+)
+
+const (
+	defaultAsyncQueueSize = 1024
+)
+
+// LevelWriter can be implemented by an output to receive the level of each
+// entry along with the formatted entry. Loggers will call WriteLevel instead
+// of Write when their output implements it.
+type LevelWriter interface {
+	io.Writer
+	WriteLevel(lvl Level, p []byte) (n int, err error)
+}
+
+// AsyncOptions changes how an AsyncWriter queues entries.
+type AsyncOptions struct {
+	// QueueSize is the maximum number of entries that can be waiting to be
+	// written, if it is 0 then 1024 is used.
+	QueueSize int
+
+	// Overflow is what happens to new entries when the queue is full.
+	Overflow OverflowPolicy
+
+	// DropLevel is the minimum level of entries that will be kept when the
+	// queue is full and Overflow is OverflowPolicy_DropBelowLevel.
+	DropLevel Level
+}
+
+type asyncEntry struct {
+	level Level
+	data  []byte
+}
+
+// AsyncWriter is an output that writes entries to another writer on a
+// background goroutine, so that a slow terminal or disk does not stall the
+// goroutines that are logging. Entries are kept in a bounded queue, when the
+// queue is full the OverflowPolicy decides whether the caller waits or an entry
+// is dropped.
+//
+// Flush or Close should be called before the program exits so that queued
+// entries are not lost. The package level Sync will flush every AsyncWriter
+// that has been given to a logger.
+type AsyncWriter struct {
+	w         io.Writer
+	overflow  OverflowPolicy
+	dropLevel Level
+
+	// dropped is the number of entries that have been dropped, it is only
+	// accessed atomically.
+	dropped uint64
+
+	lock     sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	idle     *sync.Cond
+
+	// queue is a ring buffer of entries, the byte slices are reused so that
+	// queueing an entry does not allocate once the queue has warmed up.
+	queue   []asyncEntry
+	head    int
+	length  int
+	writing bool
+	closed  bool
+	done    chan struct{}
+
+	// syncLock serializes writes to w, once the writer has been closed entries
+	// are written by the caller instead of the background goroutine.
+	syncLock sync.Mutex
+}
+
+// NewAsyncWriter will create an AsyncWriter that writes to the writer provided
+// and start its background goroutine.
+//
+//	async := timber.NewAsyncWriter(os.Stdout, timber.AsyncOptions{
+//		Overflow: timber.OverflowPolicy_DropBelowLevel,
+//		DropLevel: timber.Level_Warning,
+//	})
+//	defer async.Close()
+//	timber.SetOutput(async)
+func NewAsyncWriter(w io.Writer, options AsyncOptions) *AsyncWriter {
+	size := options.QueueSize
+	if size <= 0 {
+		size = defaultAsyncQueueSize
+	}
+	a := &AsyncWriter{
+		w:         w,
+		overflow:  options.Overflow,
+		dropLevel: options.DropLevel,
+		queue:     make([]asyncEntry, size),
+		done:      make(chan struct{}),
+	}
+	a.notEmpty = sync.NewCond(&a.lock)
+	a.notFull = sync.NewCond(&a.lock)
+	a.idle = sync.NewCond(&a.lock)
+	go a.run()
+	return a
+}
+
+// Write will queue the bytes provided to be written. Writes without a level are
+// never dropped by OverflowPolicy_DropBelowLevel.
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	return a.WriteLevel(0, p)
+}
+
+// WriteLevel will queue the bytes provided to be written. The bytes are copied
+// so the caller is free to reuse them. If the writer has been closed then the
+// bytes are written synchronously instead.
+func (a *AsyncWriter) WriteLevel(lvl Level, p []byte) (int, error) {
+	a.lock.Lock()
+	for !a.closed && a.length == len(a.queue) {
+		switch {
+		case a.overflow == OverflowPolicy_DropNewest,
+			a.overflow == OverflowPolicy_DropBelowLevel && lvl != 0 && lvl < a.dropLevel:
+			a.lock.Unlock()
+			atomic.AddUint64(&a.dropped, 1)
+			return len(p), nil
+		case a.overflow == OverflowPolicy_DropOldest:
+			a.head = (a.head + 1) % len(a.queue)
+			a.length--
+			atomic.AddUint64(&a.dropped, 1)
+		default:
+			a.notFull.Wait()
+		}
+	}
+	if a.closed {
+		a.lock.Unlock()
+		return a.write(lvl, p)
+	}
+	entry := &a.queue[(a.head+a.length)%len(a.queue)]
+	entry.level = lvl
+	entry.data = append(entry.data[:0], p...)
+	a.length++
+	a.notEmpty.Signal()
+	a.lock.Unlock()
+	return len(p), nil
+}
+
+// run will write queued entries until the writer is closed and the queue is
+// empty.
+func (a *AsyncWriter) run() {
+	defer close(a.done)
+	var data []byte
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for {
+		for a.length == 0 {
+			a.writing = false
+			a.idle.Broadcast()
+			if a.closed {
+				return
+			}
+			a.notEmpty.Wait()
+		}
+		a.writing = true
+
+		// Swap the buffer of the entry with our own so that the queue keeps a
+		// buffer to reuse and the entry can be written without holding the lock.
+		entry := &a.queue[a.head]
+		lvl := entry.level
+		data, entry.data = entry.data, data[:0]
+		a.head = (a.head + 1) % len(a.queue)
+		a.length--
+		a.notFull.Signal()
+		a.lock.Unlock()
+
+		_, _ = a.write(lvl, data)
+
+		a.lock.Lock()
+	}
+}
+
+// write will write an entry to the underlying writer, passing the level along
+// if the writer is a LevelWriter.
+func (a *AsyncWriter) write(lvl Level, p []byte) (int, error) {
+	a.syncLock.Lock()
+	defer a.syncLock.Unlock()
+	if lw, ok := a.w.(LevelWriter); ok && lvl != 0 {
+		return lw.WriteLevel(lvl, p)
+	}
+	return a.w.Write(p)
+}
+
+// Flush will wait for every entry that has been queued to be written, and then
+// sync the underlying writer if it implements Sync() error or Flush() error. If
+// the context is done before the queue is empty then the context's error is
+// returned, entries that are still queued will continue to be written.
+func (a *AsyncWriter) Flush(ctx context.Context) error {
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		a.lock.Lock()
+		defer a.lock.Unlock()
+		for a.length > 0 || a.writing {
+			a.idle.Wait()
+		}
+	}()
+	select {
+	case <-drained:
+		return syncSink(a.w)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close will stop accepting new entries, wait for every queued entry to be
+// written and stop the background goroutine. The underlying writer is synced
+// but it is not closed. Entries written after Close are written synchronously.
+func (a *AsyncWriter) Close() error {
+	a.lock.Lock()
+	if !a.closed {
+		a.closed = true
+		a.notEmpty.Signal()
+		a.notFull.Broadcast()
+	}
+	a.lock.Unlock()
+	<-a.done
+	return syncSink(a.w)
+}
+
+// Dropped will return the number of entries that have been dropped because
+// the queue was full.
+func (a *AsyncWriter) Dropped() uint64 {
+	return atomic.LoadUint64(&a.dropped)
+}
@@ -0,0 +1,190 @@
+package timber
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// gatedWriter blocks every write until the gate is opened, so that tests can
+// fill the queue of an AsyncWriter.
+type gatedWriter struct {
+	gate    chan struct{}
+	started chan struct{}
+	lock    sync.Mutex
+	buf     bytes.Buffer
+	levels  []Level
+	synced  int
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{
+		gate:    make(chan struct{}),
+		started: make(chan struct{}, 1),
+	}
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	return g.WriteLevel(0, p)
+}
+
+func (g *gatedWriter) WriteLevel(lvl Level, p []byte) (int, error) {
+	select {
+	case g.started <- struct{}{}:
+	default:
+	}
+	<-g.gate
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.levels = append(g.levels, lvl)
+	return g.buf.Write(p)
+}
+
+func (g *gatedWriter) Sync() error {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.synced++
+	return nil
+}
+
+func (g *gatedWriter) String() string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.buf.String()
+}
+
+// fill will write the first entry and wait for the background goroutine to
+// start writing it, and then write enough entries to fill the queue.
+func fill(t *testing.T, a *AsyncWriter, g *gatedWriter, size int) {
+	_, _ = a.WriteLevel(Level_Info, []byte("writing\n"))
+	select {
+	case <-g.started:
+	case <-time.After(time.Second):
+		t.Fatal("background goroutine did not start writing")
+	}
+	for i := 0; i < size; i++ {
+		_, _ = a.WriteLevel(Level_Info, []byte("queued\n"))
+	}
+}
+
+func TestAsyncWriter(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("logger", func(t *testing.T) {
+		buf := &syncBuffer{}
+		async := NewAsyncWriter(buf, AsyncOptions{})
+		log := New().SetOutput(async).SetColor(false)
+		for i := 0; i < 100; i++ {
+			log.Infof("entry %d", i)
+		}
+		assert.NoError(t, async.Flush(context.Background()))
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if assert.Len(t, lines, 100) {
+			for i, line := range lines {
+				assert.True(t, strings.HasSuffix(line, "entry "+strconv.Itoa(i)), line)
+			}
+		}
+		assert.Equal(t, 1, buf.synced)
+		assert.NoError(t, async.Close())
+		assert.Equal(t, uint64(0), async.Dropped())
+	})
+
+	t.Run("levels", func(t *testing.T) {
+		g := newGatedWriter()
+		close(g.gate)
+		async := NewAsyncWriter(g, AsyncOptions{})
+		New().SetOutput(async).Warning("test")
+		_, _ = async.Write([]byte("raw\n"))
+		assert.NoError(t, async.Close())
+		assert.Equal(t, []Level{Level_Warning, 0}, g.levels)
+	})
+
+	t.Run("drop newest", func(t *testing.T) {
+		g := newGatedWriter()
+		async := NewAsyncWriter(g, AsyncOptions{QueueSize: 2, Overflow: OverflowPolicy_DropNewest})
+		fill(t, async, g, 2)
+		_, _ = async.WriteLevel(Level_Error, []byte("dropped\n"))
+		assert.Equal(t, uint64(1), async.Dropped())
+		close(g.gate)
+		assert.NoError(t, async.Close())
+		assert.Equal(t, "writing\nqueued\nqueued\n", g.String())
+	})
+
+	t.Run("drop oldest", func(t *testing.T) {
+		g := newGatedWriter()
+		async := NewAsyncWriter(g, AsyncOptions{QueueSize: 2, Overflow: OverflowPolicy_DropOldest})
+		fill(t, async, g, 2)
+		_, _ = async.WriteLevel(Level_Error, []byte("newest\n"))
+		assert.Equal(t, uint64(1), async.Dropped())
+		close(g.gate)
+		assert.NoError(t, async.Close())
+		assert.Equal(t, "writing\nqueued\nnewest\n", g.String())
+	})
+
+	t.Run("drop below level", func(t *testing.T) {
+		g := newGatedWriter()
+		async := NewAsyncWriter(g, AsyncOptions{
+			QueueSize: 2,
+			Overflow:  OverflowPolicy_DropBelowLevel,
+			DropLevel: Level_Warning,
+		})
+		fill(t, async, g, 2)
+		_, _ = async.WriteLevel(Level_Info, []byte("dropped\n"))
+		assert.Equal(t, uint64(1), async.Dropped())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = async.WriteLevel(Level_Error, []byte("kept\n"))
+		}()
+		select {
+		case <-done:
+			t.Fatal("entry at the drop level did not wait for the queue")
+		case <-time.After(10 * time.Millisecond):
+		}
+		close(g.gate)
+		<-done
+		assert.NoError(t, async.Close())
+		assert.Equal(t, "writing\nqueued\nqueued\nkept\n", g.String())
+		assert.Equal(t, uint64(1), async.Dropped())
+	})
+
+	t.Run("flush timeout", func(t *testing.T) {
+		g := newGatedWriter()
+		async := NewAsyncWriter(g, AsyncOptions{})
+		fill(t, async, g, 1)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		assert.Equal(t, context.DeadlineExceeded, async.Flush(ctx))
+		close(g.gate)
+		assert.NoError(t, async.Flush(context.Background()))
+		assert.Equal(t, "writing\nqueued\n", g.String())
+		assert.Equal(t, 1, g.synced)
+		assert.NoError(t, async.Close())
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		buf := &syncBuffer{}
+		async := NewAsyncWriter(buf, AsyncOptions{})
+		defer async.Close()
+		New().SetOutput(async).Info("synced")
+		assert.NoError(t, Sync())
+		assert.Contains(t, buf.String(), "synced")
+		assert.Equal(t, 1, buf.synced)
+	})
+
+	t.Run("write after close", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		async := NewAsyncWriter(buf, AsyncOptions{})
+		assert.NoError(t, async.Close())
+		assert.NoError(t, async.Close())
+		_, err := async.Write([]byte("after close\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "after close\n", buf.String())
+	})
+}
@@ -0,0 +1,138 @@
+package timber
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+var raceEnabled = false
+
+func TestDisabledLevelAllocations(t *testing.T) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetLevel(Level_Error).With(Keys{"a": 1})
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Debug("test")
+		log.Debugf("test")
+		log.DebugEx(nil, "test")
+		log.DebugCtx(context.Background(), "test")
+		log.DebugE(io.EOF, "test")
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestEnabledLevelAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("allocations cannot be counted with the race detector enabled")
+	}
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetColor(false)
+	allocs := testing.AllocsPerRun(100, func() {
+		log.Info("test")
+	})
+	// The only allocations should come from resolving the caller.
+	callerAllocs := testing.AllocsPerRun(100, func() {
+		CallerInfo(1)
+	})
+	assert.Equal(t, callerAllocs, allocs)
+
+	// Without the caller nothing should be allocated at all.
+	log.SetCaller(CallerMode_Off, false)
+	allocs = testing.AllocsPerRun(100, func() {
+		log.Info("test")
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func BenchmarkLogger_Disabled(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetLevel(Level_Error)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Debugf("test")
+	}
+}
+
+func BenchmarkLogger_DisabledWithArgs(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetLevel(Level_Error)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Debugf("test %d %s", i, "format")
+	}
+}
+
+func BenchmarkLogger_Text(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetColor(false)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Info("test")
+	}
+}
+
+func BenchmarkLogger_TextWithKeys(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetColor(false).With(Keys{
+		"request": 1234,
+		"user":    "test",
+	})
+	keys := Keys{"err": errors.New("bad")}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.InfoEx(keys, "test")
+	}
+}
+
+func BenchmarkLogger_TextColor(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetColor(true).With(Keys{
+		"request": 1234,
+	})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Info("test")
+	}
+}
+
+func BenchmarkLogger_JSON(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetFormatter(&JSONFormatter{})
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Info("test")
+	}
+}
+
+func BenchmarkLogger_JSONWithKeys(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetFormatter(&JSONFormatter{}).With(Keys{
+		"request": 1234,
+		"user":    "test",
+	})
+	keys := Keys{"err": errors.New("bad")}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.InfoEx(keys, "test")
+	}
+}
+
+func BenchmarkLogger_CallerOff(b *testing.B) {
+	SetLevel(Level_Trace)
+	log := New().SetOutput(ioutil.Discard).SetColor(false).SetCaller(CallerMode_Off, false)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Info("test")
+	}
+}
@@ -1,31 +1,211 @@
 package timber
 
 import (
-	"fmt"
+	"net/url"
 	"os"
 	"runtime"
+	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync"
 )
 
+// CallerMode changes how the caller of each entry is written, see SetCaller.
+type CallerMode int
+
+const (
+	// CallerMode_Full will write the full path of the file, only removing
+	// $GOPATH/src/ when the file is inside the GOPATH. This is the default.
+	CallerMode_Full CallerMode = iota + 1
+
+	// CallerMode_Short will write only the name of the file, like
+	// "main.go:12".
+	CallerMode_Short
+
+	// CallerMode_Module will write the path of the file relative to the root of
+	// the main module, like "internal/server/handler.go:12". Files in other
+	// modules or the standard library are written with the import path of their
+	// package, like "github.com/lib/pq/conn.go:12".
+	CallerMode_Module
+
+	// CallerMode_Off will not capture the caller of entries at all, which
+	// avoids walking the stack for every entry that is written.
+	CallerMode_Off
+)
+
+// callerSettings is how the caller of entries is written, if the mode is 0 then
+// the settings are inherited.
+type callerSettings struct {
+	mode     CallerMode
+	function bool
+}
+
+var (
+	callerCapture = callerSettings{
+		mode: CallerMode_Full,
+	}
+	callerCaptureLock sync.RWMutex
+
+	// buildInfoOnce reads the import paths of the main module and the main
+	// package the first time that they are needed.
+	buildInfoOnce sync.Once
+	mainModule    string
+	mainPackage   string
+)
+
+// SetCaller will change how the caller of each entry is written by every logger
+// that has not had its own caller settings changed via Logger.SetCaller. If the
+// function is true then the name of the function that wrote the entry is
+// included, like "server.(*Handler).ServeHTTP". Setting the mode to 0 will use
+// CallerMode_Full.
+//
+//	timber.SetCaller(timber.CallerMode_Module, true)
+func SetCaller(mode CallerMode, function bool) {
+	if mode == 0 {
+		mode = CallerMode_Full
+	}
+	callerCaptureLock.Lock()
+	defer callerCaptureLock.Unlock()
+	callerCapture = callerSettings{
+		mode:     mode,
+		function: function,
+	}
+}
+
+// SetCaller will change how the caller of each entry is written by this logger
+// and any loggers created from it via With. If the function is true then the
+// name of the function that wrote the entry is included. Setting the mode to 0
+// will make the logger inherit the caller settings again.
+func (l *logger) SetCaller(mode CallerMode, function bool) Logger {
+	l.callerLock.Lock()
+	defer l.callerLock.Unlock()
+	l.caller = callerSettings{
+		mode:     mode,
+		function: function,
+	}
+	return l
+}
+
+func (l *logger) getCallerSettings() callerSettings {
+	for lg := l; lg != nil; lg = lg.parent {
+		lg.callerLock.RLock()
+		settings := lg.caller
+		lg.callerLock.RUnlock()
+		if settings.mode != 0 {
+			return settings
+		}
+	}
+	callerCaptureLock.RLock()
+	defer callerCaptureLock.RUnlock()
+	return callerCapture
+}
+
+// CallerInfo will return the file and line number of the caller at the index
+// provided, 0 is CallerInfo itself. The file is written the same way as
+// CallerMode_Full.
 func CallerInfo(stackIndex int) string {
-	file := ""
-	line := 0
-	ok := false
+	caller, _ := callerInfo(stackIndex+1, callerSettings{mode: CallerMode_Full})
+	return caller
+}
 
-	_, file, line, ok = runtime.Caller(stackIndex)
-	if !ok {
-		// The breaks below failed to terminate the loop, and we ran off the
-		// end of the call stack.
-		return "unknown:0"
+// callerInfo will return the caller and function name at the index provided
+// formatted with the settings provided, 0 is callerInfo itself. The function
+// name is only returned if the settings include it.
+func callerInfo(stackIndex int, settings callerSettings) (string, string) {
+	var pcs [1]uintptr
+	// Skip runtime.Callers as well.
+	if runtime.Callers(stackIndex+1, pcs[:]) == 0 {
+		// We ran off the end of the call stack.
+		return "unknown:0", ""
 	}
+	frame, _ := runtime.CallersFrames(pcs[:]).Next()
 
 	// This is a huge edge case, but it should be handled anyway.
-	if file == "<autogenerated>" {
-		return "unknown:0"
+	if frame.File == "" || frame.File == "<autogenerated>" {
+		return "unknown:0", ""
+	}
+
+	function := ""
+	if settings.function {
+		function = shortFunctionName(frame.Function)
+	}
+	return formatCallerMode(settings.mode, frame.Function, frame.File, frame.Line), function
+}
+
+// formatCaller will format the file and line number of a caller the same way
+// that CallerInfo does.
+func formatCaller(file string, line int) string {
+	if goPath := os.Getenv("GOPATH"); len(goPath) > 0 {
+		if i := strings.LastIndex(file, goPath+"/src/"); i >= 0 {
+			file = file[i+len(goPath)+len("/src/"):]
+		}
+	}
+	return file + ":" + strconv.Itoa(line)
+}
+
+// formatCallerMode will format the file and line number of a caller using the
+// mode provided. The name of the function is used to find the package of the
+// file for CallerMode_Module.
+func formatCallerMode(mode CallerMode, function, file string, line int) string {
+	switch mode {
+	case CallerMode_Short:
+		return file[strings.LastIndexByte(file, '/')+1:] + ":" + strconv.Itoa(line)
+	case CallerMode_Module:
+		return modulePath(function, file) + ":" + strconv.Itoa(line)
+	default:
+		return formatCaller(file, line)
+	}
+}
+
+// modulePath will return the path of the file relative to the main module. The
+// import path of the package is taken from the function name, since the path of
+// the file on disk depends on where the program was built.
+func modulePath(function, file string) string {
+	pkg := packagePath(function)
+	if len(pkg) == 0 {
+		return file
 	}
+	buildInfoOnce.Do(readBuildInfo)
+	if pkg == "main" && len(mainPackage) > 0 {
+		pkg = mainPackage
+	}
+	path := pkg + "/" + file[strings.LastIndexByte(file, '/')+1:]
+	if len(mainModule) > 0 && strings.HasPrefix(path, mainModule+"/") {
+		path = path[len(mainModule)+1:]
+	}
+	return path
+}
+
+func readBuildInfo() {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	mainModule, mainPackage = info.Main.Path, info.Path
+}
+
+// packagePath will return the import path of the package that the function is
+// in, like "github.com/elliotcourant/timber" for
+// "github.com/elliotcourant/timber.(*logger).Info". The compiler escapes dots
+// in the last element of the path, like "gopkg.in/yaml%2ev3.(*Decoder).Decode",
+// so these are unescaped.
+func packagePath(function string) string {
+	slash := strings.LastIndexByte(function, '/') + 1
+	dot := strings.IndexByte(function[slash:], '.')
+	if dot < 0 {
+		return ""
+	}
+	pkg := function[:slash+dot]
+	if strings.IndexByte(pkg, '%') >= 0 {
+		if unescaped, err := url.PathUnescape(pkg); err == nil {
+			pkg = unescaped
+		}
+	}
+	return pkg
+}
 
-	goPath := os.Getenv("GOPATH")
-	parts := strings.Split(file, goPath+"/src/")
-	file = parts[len(parts)-1]
-	return fmt.Sprintf("%s:%d", file, line)
+// shortFunctionName will remove the directory of the package from the name of
+// the function, like "timber.(*logger).Info".
+func shortFunctionName(function string) string {
+	return function[strings.LastIndexByte(function, '/')+1:]
 }
@@ -1,7 +1,9 @@
 package timber
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
+	"strings"
 	"testing"
 )
 
@@ -10,4 +12,110 @@ func TestCallerInfo(t *testing.T) {
 		info := CallerInfo(19999)
 		assert.Equal(t, "unknown:0", info)
 	})
+
+	t.Run("caller", func(t *testing.T) {
+		info := CallerInfo(1)
+		assert.True(t, strings.HasSuffix(info, "/caller_test.go:17"), info)
+	})
+}
+
+func TestFormatCallerMode(t *testing.T) {
+	buildInfoOnce.Do(readBuildInfo)
+	module, pkg := mainModule, mainPackage
+	mainModule, mainPackage = "github.com/elliotcourant/timber", "github.com/elliotcourant/timber/cmd/server"
+	defer func() {
+		mainModule, mainPackage = module, pkg
+	}()
+
+	for _, item := range []struct {
+		mode     CallerMode
+		function string
+		file     string
+		expected string
+	}{
+		{CallerMode_Full, "main.main", "/home/ci/work/cmd/server/main.go", "/home/ci/work/cmd/server/main.go:12"},
+		{CallerMode_Short, "main.main", "/home/ci/work/cmd/server/main.go", "main.go:12"},
+		{CallerMode_Module, "main.main", "/home/ci/work/cmd/server/main.go", "cmd/server/main.go:12"},
+		{
+			CallerMode_Module,
+			"github.com/elliotcourant/timber/internal/server.(*Handler).ServeHTTP",
+			"/home/ci/work/internal/server/handler.go",
+			"internal/server/handler.go:12",
+		},
+		{
+			CallerMode_Module,
+			"github.com/lib/pq.(*conn).Query",
+			"/home/ci/go/pkg/mod/github.com/lib/pq@v1.10.0/conn.go",
+			"github.com/lib/pq/conn.go:12",
+		},
+		{
+			CallerMode_Module,
+			"gopkg.in/yaml%2ev3.(*Decoder).Decode",
+			"/home/ci/go/pkg/mod/gopkg.in/yaml.v3@v3.0.1/decode.go",
+			"gopkg.in/yaml.v3/decode.go:12",
+		},
+		{CallerMode_Module, "net/http.HandlerFunc.ServeHTTP", "/usr/local/go/src/net/http/server.go", "net/http/server.go:12"},
+		{CallerMode_Module, "", "/home/ci/work/main.go", "/home/ci/work/main.go:12"},
+	} {
+		assert.Equal(t, item.expected, formatCallerMode(item.mode, item.function, item.file, 12))
+	}
+}
+
+func TestShortFunctionName(t *testing.T) {
+	assert.Equal(t, "server.(*Handler).ServeHTTP", shortFunctionName("github.com/elliotcourant/timber/internal/server.(*Handler).ServeHTTP"))
+	assert.Equal(t, "main.main", shortFunctionName("main.main"))
+}
+
+func TestLogger_SetCaller(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+	log := New().SetOutput(buf).SetFormatter(f)
+
+	t.Run("short with function", func(t *testing.T) {
+		f.entries = nil
+		log.SetCaller(CallerMode_Short, true)
+		defer log.SetCaller(0, false)
+		log.Info("test")
+		log.With(Keys{"a": 1}).Info("inherited")
+		if assert.Len(t, f.entries, 2) {
+			for _, entry := range f.entries {
+				assert.True(t, strings.HasPrefix(entry.Caller, "caller_test.go:"), entry.Caller)
+				assert.Equal(t, "timber.TestLogger_SetCaller.func1", entry.Function)
+			}
+		}
+	})
+
+	t.Run("module", func(t *testing.T) {
+		f.entries = nil
+		SetCaller(CallerMode_Module, false)
+		defer SetCaller(0, false)
+		log.Info("test")
+		if assert.Len(t, f.entries, 1) {
+			assert.True(t, strings.HasPrefix(f.entries[0].Caller, "caller_test.go:"), f.entries[0].Caller)
+			assert.Empty(t, f.entries[0].Function)
+		}
+	})
+
+	t.Run("off", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		log := New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true}).SetCaller(CallerMode_Off, true)
+		log.Warning("test")
+		assert.Equal(t, "[WARN] test\n", buf.String())
+	})
+}
+
+func TestLog_Caller(t *testing.T) {
+	SetLevel(Level_Trace)
+	output, formatter := GetOutput(), GetFormatter()
+	defer func() {
+		SetOutput(output)
+		SetFormatter(formatter)
+	}()
+	buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+	SetOutput(buf)
+	SetFormatter(f)
+	Log(Level_Info, "test")
+	if assert.Len(t, f.entries, 1) {
+		assert.Contains(t, f.entries[0].Caller, "caller_test.go:")
+	}
 }
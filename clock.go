@@ -0,0 +1,82 @@
+package timber
+
+import (
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// TimeLayout_RFC3339Nano will write times using time.RFC3339Nano.
+	TimeLayout_RFC3339Nano = time.RFC3339Nano
+
+	// TimeLayout_UnixMillis will write times as the number of milliseconds
+	// since the unix epoch.
+	TimeLayout_UnixMillis = "unixmillis"
+
+	// TimeLayout_Elapsed will write times as the duration since the program
+	// started, or since the clock was last changed via SetClock.
+	TimeLayout_Elapsed = "elapsed"
+)
+
+// Clock provides the time that is used for each entry. It can be replaced via
+// SetClock so that tests can produce deterministic output.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+var (
+	clock     Clock = systemClock{}
+	startTime       = time.Now()
+	clockLock sync.RWMutex
+)
+
+// SetClock will change the clock that is used to get the time of every entry.
+// The start time that is used by TimeLayout_Elapsed is also reset to the current
+// time of the new clock. If the clock is nil then the system clock is restored.
+func SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	clockLock.Lock()
+	defer clockLock.Unlock()
+	clock = c
+	startTime = c.Now()
+}
+
+func now() time.Time {
+	clockLock.RLock()
+	defer clockLock.RUnlock()
+	return clock.Now()
+}
+
+func getStartTime() time.Time {
+	clockLock.RLock()
+	defer clockLock.RUnlock()
+	return startTime
+}
+
+// appendTime will append the time to the bytes provided using the layout
+// provided, which can either be one of the special TimeLayout values or any
+// layout accepted by time.Format. If utc is false then the time is converted to
+// local time first.
+func appendTime(b []byte, t time.Time, layout string, utc bool) []byte {
+	switch layout {
+	case TimeLayout_UnixMillis:
+		return strconv.AppendInt(b, t.UnixNano()/int64(time.Millisecond), 10)
+	case TimeLayout_Elapsed:
+		return append(b, t.Sub(getStartTime()).String()...)
+	}
+	if utc {
+		t = t.UTC()
+	} else {
+		t = t.Local()
+	}
+	return t.AppendFormat(b, layout)
+}
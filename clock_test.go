@@ -0,0 +1,60 @@
+package timber
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+func TestSetClock(t *testing.T) {
+	defer SetClock(nil)
+	SetLevel(Level_Trace)
+
+	c := &fixedClock{now: time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)}
+	SetClock(c)
+	assert.Equal(t, c.now, now())
+
+	t.Run("entry time", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		New().SetOutput(buf).SetFormatter(&JSONFormatter{UTC: true}).Info("test")
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, "2019-05-01T12:00:00Z", obj["time"])
+	})
+
+	t.Run("unix millis", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		New().SetOutput(buf).SetFormatter(&JSONFormatter{TimeLayout: TimeLayout_UnixMillis}).Info("test")
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, float64(1556712000000), obj["time"])
+	})
+
+	t.Run("elapsed", func(t *testing.T) {
+		c.now = c.now.Add(1500 * time.Millisecond)
+		buf := bytes.NewBuffer(nil)
+		New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{TimeLayout: TimeLayout_Elapsed}).Info("test")
+		assert.Contains(t, buf.String(), "1.5s [INFO]")
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		SetClock(nil)
+		assert.WithinDuration(t, time.Now(), now(), time.Minute)
+	})
+}
+
+func TestAppendTime(t *testing.T) {
+	tm := time.Date(2019, 5, 1, 12, 0, 0, 0, time.FixedZone("test", 3600))
+	assert.Equal(t, "2019-05-01T11:00:00Z", string(appendTime(nil, tm, time.RFC3339, true)))
+	assert.Equal(t, tm.Local().Format(time.RFC3339), string(appendTime(nil, tm, time.RFC3339, false)))
+}
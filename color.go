@@ -0,0 +1,98 @@
+package timber
+
+import (
+	"golang.org/x/term"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+type colorMode int
+
+const (
+	// colorMode_Auto will inherit the color setting from the parent logger, and
+	// if no logger has it set then it is detected from the environment and the
+	// output.
+	colorMode_Auto colorMode = iota
+	colorMode_On
+	colorMode_Off
+)
+
+var (
+	// envColorMode is read from the NO_COLOR and FORCE_COLOR environment
+	// variables when the program starts.
+	envColorMode = getEnvColorMode()
+
+	// terminals caches whether or not each file that has been used as an output
+	// is a terminal so that it only needs to be checked once.
+	terminals sync.Map
+)
+
+// getEnvColorMode will return the color mode requested by the environment. If
+// NO_COLOR is set then colors are disabled, if FORCE_COLOR is set to anything
+// other than 0 or false then colors are enabled.
+func getEnvColorMode() colorMode {
+	if len(os.Getenv("NO_COLOR")) > 0 {
+		return colorMode_Off
+	}
+	switch force := strings.ToLower(os.Getenv("FORCE_COLOR")); force {
+	case "":
+		return colorMode_Auto
+	case "0", "false":
+		return colorMode_Off
+	default:
+		return colorMode_On
+	}
+}
+
+// isTerminal will return true if the writer is a file that is a terminal. Other
+// character devices like /dev/null are not terminals. An AsyncWriter is a
+// terminal if the writer it wraps is.
+func isTerminal(w io.Writer) bool {
+	if a, ok := w.(*AsyncWriter); ok {
+		w = a.w
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	if terminal, ok := terminals.Load(f); ok {
+		return terminal.(bool)
+	}
+	terminal := term.IsTerminal(int(f.Fd()))
+	terminals.Store(f, terminal)
+	return terminal
+}
+
+// SetColor will force ANSI colors to be enabled or disabled for this logger and
+// any loggers created from it via With. If it is not set then colors are only
+// used when NO_COLOR is not set and either FORCE_COLOR is set or the output of
+// the logger is a terminal.
+func (l *logger) SetColor(enabled bool) Logger {
+	l.colorLock.Lock()
+	defer l.colorLock.Unlock()
+	if enabled {
+		l.color = colorMode_On
+	} else {
+		l.color = colorMode_Off
+	}
+	return l
+}
+
+// useColor will return true if entries written to the output provided should
+// include ANSI colors.
+func (l *logger) useColor(w io.Writer) bool {
+	for lg := l; lg != nil; lg = lg.parent {
+		lg.colorLock.RLock()
+		mode := lg.color
+		lg.colorLock.RUnlock()
+		if mode != colorMode_Auto {
+			return mode == colorMode_On
+		}
+	}
+	if envColorMode != colorMode_Auto {
+		return envColorMode == colorMode_On
+	}
+	return isTerminal(w)
+}
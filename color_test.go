@@ -0,0 +1,81 @@
+package timber
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestGetEnvColorMode(t *testing.T) {
+	defer os.Setenv("NO_COLOR", os.Getenv("NO_COLOR"))
+	defer os.Setenv("FORCE_COLOR", os.Getenv("FORCE_COLOR"))
+
+	os.Setenv("NO_COLOR", "")
+	os.Setenv("FORCE_COLOR", "")
+	assert.Equal(t, colorMode_Auto, getEnvColorMode())
+
+	os.Setenv("FORCE_COLOR", "1")
+	assert.Equal(t, colorMode_On, getEnvColorMode())
+
+	os.Setenv("FORCE_COLOR", "false")
+	assert.Equal(t, colorMode_Off, getEnvColorMode())
+
+	os.Setenv("NO_COLOR", "1")
+	os.Setenv("FORCE_COLOR", "1")
+	assert.Equal(t, colorMode_Off, getEnvColorMode())
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.False(t, isTerminal(bytes.NewBuffer(nil)))
+
+	f, err := ioutil.TempFile("", "timber")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	assert.False(t, isTerminal(f))
+	assert.False(t, isTerminal(f))
+
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer null.Close()
+	assert.False(t, isTerminal(null), "a character device that is not a terminal")
+}
+
+func TestLogger_SetColor(t *testing.T) {
+	SetLevel(Level_Trace)
+	defer func(mode colorMode) {
+		envColorMode = mode
+	}(envColorMode)
+
+	t.Run("auto", func(t *testing.T) {
+		envColorMode = colorMode_Auto
+		buf := bytes.NewBuffer(nil)
+		New().SetOutput(buf).With(Keys{"a": 1}).Prefix("prefix").Error("test")
+		assert.NotContains(t, buf.String(), "\x1b[")
+
+		envColorMode = colorMode_On
+		buf.Reset()
+		New().SetOutput(buf).Error("test")
+		assert.Contains(t, buf.String(), "\x1b[")
+	})
+
+	t.Run("forced", func(t *testing.T) {
+		envColorMode = colorMode_Off
+		buf := bytes.NewBuffer(nil)
+		log := New().SetOutput(buf).SetColor(true)
+		log.With(Keys{"a": 1}).Error("test")
+		assert.Contains(t, buf.String(), "\x1b[")
+
+		envColorMode = colorMode_On
+		buf.Reset()
+		log.SetColor(false)
+		log.With(Keys{"a": 1}).Prefix("prefix").Error("test")
+		assert.NotContains(t, buf.String(), "\x1b[")
+	})
+}
@@ -0,0 +1,112 @@
+package timber
+
+import (
+	"context"
+	"sync"
+)
+
+type contextKey struct{}
+
+// ContextExtractor adds fields to entries that are written via the *Ctx methods,
+// using values from the context. Extractors should append their fields to the
+// fields provided and return the result, if the context does not have the
+// value they are looking for then the fields should be returned as is.
+type ContextExtractor func(ctx context.Context, fields []Field) []Field
+
+// ContextHook is called with every entry that is written via the *Ctx methods,
+// after it has been written. Hooks can be used to forward entries to something
+// stored in the context, like the active span of a trace. The entry is reused
+// once the hook returns, so it must not be retained.
+type ContextHook func(ctx context.Context, entry *Entry)
+
+var (
+	contextExtractors     []ContextExtractor
+	contextExtractorsLock sync.RWMutex
+
+	contextHooks     []ContextHook
+	contextHooksLock sync.RWMutex
+)
+
+// NewContext will return a copy of the context that carries the logger
+// provided. The logger can be retrieved via FromContext, and the package level
+// *Ctx functions will write to it.
+//
+//	log := timber.With(timber.Keys{"requestId": id})
+//	ctx = timber.NewContext(ctx, log)
+//	...
+//	timber.InfoCtx(ctx, "loaded %d rows", n)
+func NewContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext will return the logger that was added to the context via
+// NewContext. If the context does not have a logger then the global logger is
+// returned.
+func FromContext(ctx context.Context) Logger {
+	if ctx != nil {
+		if l, ok := ctx.Value(contextKey{}).(Logger); ok {
+			return l
+		}
+	}
+	return defaultLogger
+}
+
+// RegisterContextExtractor will add an extractor that is called for every entry
+// written via the *Ctx methods. Extractors are called in the order they were
+// registered.
+func RegisterContextExtractor(extractor ContextExtractor) {
+	contextExtractorsLock.Lock()
+	defer contextExtractorsLock.Unlock()
+	contextExtractors = append(contextExtractors, extractor)
+}
+
+// RegisterContextHook will add a hook that is called for every entry written
+// via the *Ctx methods. Hooks are called in the order they were registered.
+func RegisterContextHook(hook ContextHook) {
+	contextHooksLock.Lock()
+	defer contextHooksLock.Unlock()
+	contextHooks = append(contextHooks, hook)
+}
+
+// ContextValue will create an extractor that adds the value stored in the
+// context under the context key provided as a field with the key provided. If
+// the context does not have the value then no field is added.
+//
+//	timber.RegisterContextExtractor(timber.ContextValue("requestId", requestIDKey{}))
+func ContextValue(key string, contextKey interface{}) ContextExtractor {
+	return func(ctx context.Context, fields []Field) []Field {
+		if v := ctx.Value(contextKey); v != nil {
+			fields = append(fields, Any(key, v))
+		}
+		return fields
+	}
+}
+
+// appendContextFields will append the fields of every registered extractor to
+// the fields provided.
+func appendContextFields(ctx context.Context, fields []Field) []Field {
+	if ctx == nil {
+		return fields
+	}
+	contextExtractorsLock.RLock()
+	defer contextExtractorsLock.RUnlock()
+	for _, extractor := range contextExtractors {
+		fields = extractor(ctx, fields)
+	}
+	return fields
+}
+
+// runContextHooks will call every registered hook with the entry provided.
+func runContextHooks(ctx context.Context, entry *Entry) {
+	contextHooksLock.RLock()
+	defer contextHooksLock.RUnlock()
+	for _, hook := range contextHooks {
+		hook(ctx, entry)
+	}
+}
+
+// logCtx will write an entry with the fields of the context provided.
+func (l *logger) logCtx(ctx context.Context, stack int, lvl Level, msg string) {
+	var tmp [8]Field
+	l.logContext(ctx, stack+1, lvl, nil, appendContextFields(ctx, tmp[:0]), []interface{}{msg})
+}
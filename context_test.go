@@ -0,0 +1,145 @@
+package timber
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type requestIDKey struct{}
+
+type userIDKey struct{}
+
+// ctxLogger is a Logger that was not created by timber, it records the
+// messages written via WarningCtx.
+type ctxLogger struct {
+	Logger
+	messages []string
+}
+
+func (l *ctxLogger) WarningCtx(ctx context.Context, msg string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(msg, args...))
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, defaultLogger, FromContext(context.Background()))
+	assert.Equal(t, defaultLogger, FromContext(nil))
+
+	log := New()
+	ctx := NewContext(context.Background(), log)
+	assert.Equal(t, log, FromContext(ctx))
+}
+
+func TestLogger_Ctx(t *testing.T) {
+	defer func() {
+		contextExtractors = nil
+	}()
+	SetLevel(Level_Trace)
+	RegisterContextExtractor(ContextValue("requestId", requestIDKey{}))
+	RegisterContextExtractor(ContextValue("userId", userIDKey{}))
+	RegisterContextExtractor(func(ctx context.Context, fields []Field) []Field {
+		if err := ctx.Err(); err != nil {
+			fields = append(fields, Err(err))
+		}
+		return fields
+	})
+
+	buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+	log := New().SetOutput(buf).SetFormatter(f).With(Keys{"service": "api", "userId": "inherited"})
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+	ctx = context.WithValue(ctx, userIDKey{}, 12)
+
+	t.Run("logger", func(t *testing.T) {
+		f.entries = nil
+		log.WarningCtx(ctx, "test %d", 1)
+		if assert.Len(t, f.entries, 1) {
+			entry := f.entries[0]
+			assert.Equal(t, Level_Warning, entry.Level)
+			assert.Equal(t, "test 1", entry.Message)
+			assert.Contains(t, entry.Caller, "context_test.go")
+			assert.Equal(t, []Field{String("requestId", "abc"), Int("userId", 12), String("service", "api")}, entry.Fields)
+		}
+	})
+
+	t.Run("package level", func(t *testing.T) {
+		f.entries = nil
+		cancelled, cancel := context.WithCancel(NewContext(ctx, log.With(Keys{"handler": "users"})))
+		cancel()
+		ErrorCtx(cancelled, "failed")
+		if assert.Len(t, f.entries, 1) {
+			entry := f.entries[0]
+			assert.Equal(t, Level_Error, entry.Level)
+			assert.Contains(t, entry.Caller, "context_test.go")
+			assert.Equal(t, []Field{
+				String("requestId", "abc"),
+				Int("userId", 12),
+				Err(context.Canceled),
+				String("service", "api"),
+				String("handler", "users"),
+			}, entry.Fields)
+		}
+	})
+
+	t.Run("package level custom logger", func(t *testing.T) {
+		f.entries = nil
+		custom := &ctxLogger{Logger: log}
+		WarningCtx(NewContext(ctx, custom), "test %d", 2)
+		assert.Equal(t, []string{"test 2"}, custom.messages)
+		assert.Empty(t, f.entries)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		f.entries = nil
+		called := false
+		RegisterContextExtractor(func(ctx context.Context, fields []Field) []Field {
+			called = true
+			return fields
+		})
+		log.SetLevel(Level_Error)
+		defer log.SetLevel(0)
+		log.InfoCtx(ctx, "skipped")
+		InfoCtx(NewContext(ctx, log), "skipped")
+		assert.Empty(t, f.entries)
+		assert.False(t, called)
+	})
+}
+
+func TestRegisterContextHook(t *testing.T) {
+	defer func() {
+		contextHooks = nil
+	}()
+	SetLevel(Level_Trace)
+	type hooked struct {
+		requestID interface{}
+		level     Level
+		message   string
+		caller    string
+	}
+	entries := make([]hooked, 0)
+	RegisterContextHook(func(ctx context.Context, entry *Entry) {
+		entries = append(entries, hooked{
+			requestID: ctx.Value(requestIDKey{}),
+			level:     entry.Level,
+			message:   entry.Message,
+			caller:    entry.Caller,
+		})
+	})
+
+	buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+	log := New().SetOutput(buf).SetFormatter(f)
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "abc")
+	log.ErrorCtx(ctx, "failed %s", "request")
+	log.Error("without a context")
+	log.SetLevel(Level_Critical)
+	log.ErrorCtx(ctx, "skipped")
+
+	assert.Len(t, f.entries, 2)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "abc", entries[0].requestID)
+		assert.Equal(t, Level_Error, entries[0].level)
+		assert.Equal(t, "failed request", entries[0].message)
+		assert.Contains(t, entries[0].caller, "context_test.go")
+	}
+}
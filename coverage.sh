@@ -9,4 +9,22 @@ for d in $(go list ./... | grep -v vendor); do
         cat profile.out >> coverage.txt
         rm profile.out
     fi
-done
\ No newline at end of file
+done
+# timberotel is a separate module so that timber does not depend on OpenTelemetry.
+# It needs Go 1.21, so it is skipped when testing older versions of Go.
+go_minor=$(go version | grep -oE 'go1\.[0-9]+' | head -n 1 | cut -d . -f 2)
+if [ "${go_minor:-0}" -lt 21 ]; then
+    echo "skipping timberotel, it needs Go 1.21 or newer"
+    exit 0
+fi
+# It requires a published version of timber, so a workspace is used to test it
+# against this checkout instead. The same go.work can be used for development.
+if [ ! -f go.work ]; then
+    go work init . ./timberotel
+    trap 'rm -f go.work go.work.sum' EXIT
+fi
+(cd timberotel && go test -v -race -coverprofile=../profile.out -covermode=atomic ./...)
+if [ -f profile.out ]; then
+    tail -n +2 profile.out >> coverage.txt
+    rm profile.out
+fi
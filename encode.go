@@ -0,0 +1,258 @@
+package timber
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const hex = "0123456789abcdef"
+
+// writeTextField will write the value of the field to the buffer the same way
+// that writeTextValue would write the value if it was not typed.
+func writeTextField(buf *bytes.Buffer, field Field) {
+	var tmp [64]byte
+	switch field.Type {
+	case FieldType_String:
+		buf.WriteString(field.String)
+	case FieldType_Int:
+		buf.Write(strconv.AppendInt(tmp[:0], field.Integer, 10))
+	case FieldType_Uint:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(field.Integer), 10))
+	case FieldType_Float:
+		buf.Write(strconv.AppendFloat(tmp[:0], math.Float64frombits(uint64(field.Integer)), 'g', -1, 64))
+	case FieldType_Bool:
+		buf.Write(strconv.AppendBool(tmp[:0], field.Integer == 1))
+	case FieldType_Duration:
+		buf.WriteString(time.Duration(field.Integer).String())
+	case FieldType_Time:
+		buf.Write(field.time().AppendFormat(tmp[:0], time.RFC3339Nano))
+	default:
+		writeTextValue(buf, field.Value)
+	}
+}
+
+// writeJSONField will write the value of the field to the buffer as JSON. The
+// value is written the same way that writeJSONValue would write the value if it
+// was not typed.
+func writeJSONField(buf *bytes.Buffer, field Field) {
+	var tmp [64]byte
+	switch field.Type {
+	case FieldType_String:
+		writeJSONString(buf, field.String)
+	case FieldType_Int:
+		buf.Write(strconv.AppendInt(tmp[:0], field.Integer, 10))
+	case FieldType_Uint:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(field.Integer), 10))
+	case FieldType_Float:
+		writeJSONFloat(buf, math.Float64frombits(uint64(field.Integer)), 64)
+	case FieldType_Bool:
+		buf.Write(strconv.AppendBool(tmp[:0], field.Integer == 1))
+	case FieldType_Duration:
+		writeJSONString(buf, time.Duration(field.Integer).String())
+	case FieldType_Time:
+		buf.WriteByte('"')
+		buf.Write(field.time().AppendFormat(tmp[:0], time.RFC3339Nano))
+		buf.WriteByte('"')
+	default:
+		writeJSONValue(buf, field.Value)
+	}
+}
+
+// writeTextValue will write the value to the buffer the same way that fmt.Sprint
+// would, but without allocating for the most common types.
+func writeTextValue(buf *bytes.Buffer, v interface{}) {
+	var tmp [64]byte
+	switch val := v.(type) {
+	case string:
+		buf.WriteString(val)
+	case bool:
+		buf.Write(strconv.AppendBool(tmp[:0], val))
+	case int:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int8:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int16:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int32:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int64:
+		buf.Write(strconv.AppendInt(tmp[:0], val, 10))
+	case uint:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint8:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint16:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint32:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint64:
+		buf.Write(strconv.AppendUint(tmp[:0], val, 10))
+	case float32:
+		buf.Write(strconv.AppendFloat(tmp[:0], float64(val), 'g', -1, 32))
+	case float64:
+		buf.Write(strconv.AppendFloat(tmp[:0], val, 'g', -1, 64))
+	case error:
+		if isNilPointer(val) {
+			buf.WriteString("<nil>")
+			return
+		}
+		buf.WriteString(val.Error())
+	case fmt.Stringer:
+		if isNilPointer(val) {
+			buf.WriteString("<nil>")
+			return
+		}
+		buf.WriteString(val.String())
+	default:
+		fmt.Fprint(buf, v)
+	}
+}
+
+// writeJSONValue will write the value to the buffer as JSON. The most common
+// types are written directly, anything else is encoded with encoding/json.
+// Errors and fmt.Stringers are written as strings, or null if they are a nil
+// pointer. Values that cannot be encoded are written as a string the same way
+// that fmt.Sprint would write them, so that one field cannot lose the entry.
+func writeJSONValue(buf *bytes.Buffer, v interface{}) {
+	var tmp [64]byte
+	switch val := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case string:
+		writeJSONString(buf, val)
+	case bool:
+		buf.Write(strconv.AppendBool(tmp[:0], val))
+	case int:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int8:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int16:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int32:
+		buf.Write(strconv.AppendInt(tmp[:0], int64(val), 10))
+	case int64:
+		buf.Write(strconv.AppendInt(tmp[:0], val, 10))
+	case uint:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint8:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint16:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint32:
+		buf.Write(strconv.AppendUint(tmp[:0], uint64(val), 10))
+	case uint64:
+		buf.Write(strconv.AppendUint(tmp[:0], val, 10))
+	case float32:
+		writeJSONFloat(buf, float64(val), 32)
+	case float64:
+		writeJSONFloat(buf, val, 64)
+	case error:
+		if isNilPointer(val) {
+			buf.WriteString("null")
+			return
+		}
+		writeJSONString(buf, val.Error())
+	case json.Marshaler, encoding.TextMarshaler:
+		writeJSONMarshal(buf, val)
+	case fmt.Stringer:
+		if isNilPointer(val) {
+			buf.WriteString("null")
+			return
+		}
+		writeJSONString(buf, val.String())
+	default:
+		writeJSONMarshal(buf, val)
+	}
+}
+
+// isNilPointer will return true if the value is a nil pointer stored in an
+// interface. Calling Error or String on one would usually panic, so they are
+// written as nil the same way that fmt does.
+func isNilPointer(v interface{}) bool {
+	val := reflect.ValueOf(v)
+	return val.Kind() == reflect.Ptr && val.IsNil()
+}
+
+func writeJSONMarshal(buf *bytes.Buffer, v interface{}) {
+	j, err := json.Marshal(v)
+	if err != nil {
+		writeJSONString(buf, fmt.Sprint(v))
+		return
+	}
+	buf.Write(j)
+}
+
+// writeJSONFloat will write the float as a JSON number, NaN and infinity cannot
+// be represented as numbers in JSON so they are written as strings.
+func writeJSONFloat(buf *bytes.Buffer, f float64, bitSize int) {
+	var tmp [64]byte
+	switch {
+	case math.IsNaN(f):
+		buf.WriteString(`"NaN"`)
+	case math.IsInf(f, 1):
+		buf.WriteString(`"+Inf"`)
+	case math.IsInf(f, -1):
+		buf.WriteString(`"-Inf"`)
+	default:
+		buf.Write(strconv.AppendFloat(tmp[:0], f, 'g', -1, bitSize))
+	}
+}
+
+// writeJSONString will write the string to the buffer as a quoted JSON string.
+// Invalid UTF-8 is replaced with the unicode replacement character the same way
+// that encoding/json does.
+func writeJSONString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' {
+				i++
+				continue
+			}
+			buf.WriteString(s[start:i])
+			switch b {
+			case '"', '\\':
+				buf.WriteByte('\\')
+				buf.WriteByte(b)
+			case '\n':
+				buf.WriteString(`\n`)
+			case '\r':
+				buf.WriteString(`\r`)
+			case '\t':
+				buf.WriteString(`\t`)
+			default:
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[b>>4])
+				buf.WriteByte(hex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			buf.WriteString(s[start:i])
+			buf.WriteString("\ufffd")
+		case r == '\u2028' || r == '\u2029':
+			buf.WriteString(s[start:i])
+			buf.WriteString(`\u202`)
+			buf.WriteByte(hex[r&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	buf.WriteString(s[start:])
+	buf.WriteByte('"')
+}
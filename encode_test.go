@@ -0,0 +1,109 @@
+package timber
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestWriteJSONString(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"plain",
+		`quotes " and \ slashes`,
+		"control \n \r \t \x00 \x1f",
+		"unicode \u2603 \u2028 \u2029",
+		"invalid \xff utf8",
+	} {
+		buf := bytes.NewBuffer(nil)
+		writeJSONString(buf, s)
+		var decoded string
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded), s)
+		expected, _ := json.Marshal(s)
+		assert.Equal(t, string(expected), buf.String(), s)
+	}
+}
+
+func TestWriteJSONValue(t *testing.T) {
+	for _, item := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{nil, `null`},
+		{"test", `"test"`},
+		{true, `true`},
+		{-12, `-12`},
+		{uint8(12), `12`},
+		{1.5, `1.5`},
+		{float32(0.25), `0.25`},
+		{math.NaN(), `"NaN"`},
+		{math.Inf(-1), `"-Inf"`},
+		{errors.New("bad"), `"bad"`},
+		{Level_Info, `"Info"`},
+		{time.Second, `"1s"`},
+		{time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC), `"2019-05-01T12:00:00Z"`},
+		{[]int{1, 2}, `[1,2]`},
+		{map[string]int{"a": 1}, `{"a":1}`},
+		{(*wrappedError)(nil), `null`},
+		{(*url.URL)(nil), `null`},
+	} {
+		buf := bytes.NewBuffer(nil)
+		writeJSONValue(buf, item.value)
+		assert.Equal(t, item.expected, buf.String())
+	}
+
+	buf := bytes.NewBuffer(nil)
+	ch := make(chan int)
+	writeJSONValue(buf, map[string]interface{}{"ch": ch})
+	var decoded string
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, fmt.Sprint(map[string]interface{}{"ch": ch}), decoded)
+}
+
+func TestWriteTextValue(t *testing.T) {
+	for _, item := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{"test", `test`},
+		{false, `false`},
+		{int64(-12), `-12`},
+		{uint(12), `12`},
+		{1.5, `1.5`},
+		{errors.New("bad"), `bad`},
+		{time.Second, `1s`},
+		{[]int{1, 2}, `[1 2]`},
+		{(*wrappedError)(nil), `<nil>`},
+		{(*url.URL)(nil), `<nil>`},
+	} {
+		buf := bytes.NewBuffer(nil)
+		writeTextValue(buf, item.value)
+		assert.Equal(t, item.expected, buf.String())
+	}
+}
+
+func TestLogger_NilPointers(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf := bytes.NewBuffer(nil)
+	log := New().SetOutput(buf).SetColor(false)
+
+	log.SetFormatter(&TextFormatter{DisableTime: true})
+	log.With(Keys{"url": (*url.URL)(nil)}).ErrorE((*wrappedError)(nil), "test")
+	assert.Contains(t, buf.String(), "{ error: <nil>, url: <nil> } | test\n")
+
+	buf.Reset()
+	log.SetFormatter(&JSONFormatter{})
+	log.With(Keys{"url": (*url.URL)(nil)}).ErrorE((*wrappedError)(nil), "test")
+	var obj map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+	assert.Nil(t, obj["url"])
+	assert.Contains(t, obj, "error")
+	assert.Nil(t, obj["error"])
+	assert.Equal(t, "*timber.wrappedError", obj["error_type"])
+}
@@ -0,0 +1,160 @@
+package timber
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// maxErrorChain is the most wrapped errors that will be followed, it stops
+// errors that wrap themselves from looping forever.
+const maxErrorChain = 32
+
+// errorCause will return the error that the error provided wraps, or nil if it
+// does not wrap one. Both the Unwrap method used by the errors package and the
+// Cause method used by github.com/pkg/errors are supported.
+func errorCause(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return e.Unwrap()
+	case interface{ Cause() error }:
+		return e.Cause()
+	default:
+		return nil
+	}
+}
+
+// errorChain will return the error provided followed by every error that it
+// wraps, the last error is the root cause. A nil pointer ends the chain since
+// its methods cannot be called.
+func errorChain(err error) []error {
+	chain := make([]error, 0, 4)
+	for err != nil && !isNilPointer(err) && len(chain) < maxErrorChain {
+		chain = append(chain, err)
+		err = errorCause(err)
+	}
+	return chain
+}
+
+// errorType will return the name of the type of the error, like
+// "*fs.PathError". It is the type that errors.As would need to match.
+func errorType(err error) string {
+	return reflect.TypeOf(err).String()
+}
+
+// errorStack will return the error formatted with %+v if that includes more
+// than the message of the error. Errors that record a stack trace, like those
+// from github.com/pkg/errors, write it when formatted this way. The first error
+// in the chain that implements fmt.Formatter is used, so errors wrapped with
+// fmt.Errorf still have their stack trace written.
+func errorStack(chain []error) string {
+	for _, err := range chain {
+		if _, ok := err.(fmt.Formatter); !ok {
+			continue
+		}
+		if s := fmt.Sprintf("%+v", err); s != err.Error() {
+			return s
+		}
+	}
+	return ""
+}
+
+// writeJSONErrorDetails will write the details of an error field as extra
+// fields after the field itself. The type of the error is written to key_type.
+// If the error wraps other errors then the type of the root cause is written
+// to key_kind and every error in the chain is written to key_chain. If the
+// error has a stack trace it is written to key_stack.
+func writeJSONErrorDetails(buf *bytes.Buffer, key string, err error) {
+	chain := errorChain(err)
+	writeJSONErrorKey(buf, key, "_type")
+	writeJSONString(buf, errorType(err))
+	if len(chain) > 1 {
+		writeJSONErrorKey(buf, key, "_kind")
+		writeJSONString(buf, errorType(chain[len(chain)-1]))
+		writeJSONErrorKey(buf, key, "_chain")
+		buf.WriteByte('[')
+		for i, e := range chain {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.WriteString(`{"msg":`)
+			writeJSONString(buf, e.Error())
+			buf.WriteString(`,"type":`)
+			writeJSONString(buf, errorType(e))
+			buf.WriteByte('}')
+		}
+		buf.WriteByte(']')
+	}
+	if stack := errorStack(chain); len(stack) > 0 {
+		writeJSONErrorKey(buf, key, "_stack")
+		writeJSONString(buf, stack)
+	}
+}
+
+// isErrorDetailKey will return true if the key is one that the details of an
+// error field in the entry are written to, like "error_type" for an error field
+// named "error". The chain and stack of the error are only checked when the key
+// could be one of them, since most entries do not have a field like this.
+func isErrorDetailKey(key string, entry *Entry) bool {
+	for _, field := range entry.Fields {
+		err, ok := field.Value.(error)
+		if !ok || field.Type != FieldType_Error {
+			continue
+		}
+		errKey := field.Key
+		if isBuiltInJSONKey(errKey, entry) {
+			errKey = "fields." + errKey
+		}
+		if !strings.HasPrefix(key, errKey) {
+			continue
+		}
+		switch key[len(errKey):] {
+		case "_type":
+			return true
+		case "_kind", "_chain":
+			if len(errorChain(err)) > 1 {
+				return true
+			}
+		case "_stack":
+			if len(errorStack(errorChain(err))) > 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func writeJSONErrorKey(buf *bytes.Buffer, key, suffix string) {
+	buf.WriteByte(',')
+	writeJSONString(buf, key+suffix)
+	buf.WriteByte(':')
+}
+
+// writeTextErrorStacks will write the stack trace of every error field that
+// has one below the entry, each line is indented so that it is easy to tell
+// apart from the entries around it.
+func writeTextErrorStacks(buf *bytes.Buffer, fields []Field) {
+	for _, field := range fields {
+		if field.Type != FieldType_Error {
+			continue
+		}
+		err, ok := field.Value.(error)
+		if !ok {
+			continue
+		}
+		if stack := errorStack(errorChain(err)); len(stack) > 0 {
+			writeTextBlock(buf, stack)
+		}
+	}
+}
+
+// writeTextBlock will write each line of the string provided indented by a
+// tab, followed by a newline.
+func writeTextBlock(buf *bytes.Buffer, s string) {
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		buf.WriteByte('\t')
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+}
@@ -0,0 +1,105 @@
+package timber
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+// wrappedError wraps another error the same way that fmt.Errorf with %w does.
+type wrappedError struct {
+	msg string
+	err error
+}
+
+func (e *wrappedError) Error() string { return e.msg + ": " + e.err.Error() }
+func (e *wrappedError) Unwrap() error { return e.err }
+
+// stackError records a fake stack trace the same way that github.com/pkg/errors
+// does, it is only written when formatted with %+v.
+type stackError struct {
+	err error
+}
+
+func (e stackError) Error() string { return e.err.Error() }
+func (e stackError) Cause() error  { return e.err }
+
+func (e stackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprintf(s, "%s\nmain.load\n\t/src/main.go:12", e.err)
+		return
+	}
+	io.WriteString(s, e.Error())
+}
+
+func TestErrorChain(t *testing.T) {
+	root := errors.New("root")
+	stack := stackError{root}
+	err := &wrappedError{"loading", stack}
+	assert.Equal(t, []error{err, stack, root}, errorChain(err))
+	assert.Equal(t, []error{root}, errorChain(root))
+	assert.Empty(t, errorChain(nil))
+
+	assert.Equal(t, "*timber.wrappedError", errorType(err))
+	assert.Equal(t, "root\nmain.load\n\t/src/main.go:12", errorStack(errorChain(err)))
+	assert.Equal(t, "", errorStack(errorChain(root)))
+
+	loop := &wrappedError{msg: "loop"}
+	loop.err = loop
+	assert.Len(t, errorChain(loop), maxErrorChain)
+}
+
+func TestErr_JSON(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf := bytes.NewBuffer(nil)
+	log := New().SetOutput(buf).SetFormatter(&JSONFormatter{})
+	log.ErrorE(&wrappedError{"loading", stackError{io.EOF}}, "failed to load %d", 1)
+
+	var obj map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+	assert.Equal(t, "failed to load 1", obj["msg"])
+	assert.Equal(t, "loading: EOF", obj["error"])
+	assert.Equal(t, "*timber.wrappedError", obj["error_type"])
+	assert.Equal(t, "*errors.errorString", obj["error_kind"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"msg": "loading: EOF", "type": "*timber.wrappedError"},
+		map[string]interface{}{"msg": "EOF", "type": "timber.stackError"},
+		map[string]interface{}{"msg": "EOF", "type": "*errors.errorString"},
+	}, obj["error_chain"])
+	assert.Equal(t, "EOF\nmain.load\n\t/src/main.go:12", obj["error_stack"])
+
+	buf.Reset()
+	log.With(Keys{"error_type": "shadowed", "error_types": "kept", "error_chain": "kept"}).ErrorE(io.EOF, "failed")
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"error_type"`)), buf.String())
+	obj = nil
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+	assert.Equal(t, "*errors.errorString", obj["error_type"])
+	assert.Equal(t, "shadowed", obj["fields.error_type"])
+	assert.Equal(t, "kept", obj["error_types"])
+	assert.Equal(t, "kept", obj["error_chain"], "io.EOF does not wrap anything so it has no chain")
+
+	buf.Reset()
+	log.WarningE(nil, "no error")
+	obj = nil
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+	assert.NotContains(t, obj, "error")
+	assert.NotContains(t, obj, "error_type")
+}
+
+func TestErr_Text(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf := bytes.NewBuffer(nil)
+	log := New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true})
+	log.ErrorE(stackError{io.EOF}, "failed")
+	assert.Contains(t, buf.String(), "{ error: EOF } | failed\n\tEOF\n\tmain.load\n\t\t/src/main.go:12\n")
+	assert.Contains(t, buf.String(), "error_test.go:")
+
+	buf.Reset()
+	log.ErrorE(io.EOF, "failed")
+	assert.Contains(t, buf.String(), "{ error: EOF } | failed\n")
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,272 @@
+package timber
+
+import (
+	"math"
+	"time"
+)
+
+// FieldType is how the value of a field is stored, it allows formatters to
+// write the most common types of values without reflection or allocations.
+type FieldType uint8
+
+const (
+	// FieldType_Any stores the value in Value and is written the same way a
+	// value in Keys would be.
+	FieldType_Any FieldType = iota
+	FieldType_String
+	FieldType_Int
+	FieldType_Uint
+	FieldType_Float
+	FieldType_Bool
+	FieldType_Duration
+	FieldType_Time
+	FieldType_Error
+)
+
+// Field is a single key and value that is written with an entry. Fields should
+// be created with one of the typed constructors like String or Int, which store
+// the value without boxing it in an interface.
+type Field struct {
+	Key  string
+	Type FieldType
+
+	// Integer stores the value of Int, Uint, Float, Bool and Duration fields.
+	Integer int64
+
+	// String stores the value of String fields.
+	String string
+
+	// Value stores the value of Any, Error and Time fields.
+	Value interface{}
+}
+
+// Valuer is implemented by values that are expensive to compute. The Value
+// method will only be called when an entry is actually written, after the level
+// of the logger has been checked. Valuers can be used anywhere a value can, in
+// Keys, With or Any.
+type Valuer interface {
+	Value() interface{}
+}
+
+// Lazy will wrap a function so that it is only called when an entry is
+// written, it is the simplest way to create a Valuer.
+//
+//	log.TraceEx(timber.Keys{
+//		"plan": timber.Lazy(func() interface{} { return explain(query) }),
+//	}, "executing query")
+type Lazy func() interface{}
+
+// Value will call the wrapped function.
+func (f Lazy) Value() interface{} {
+	return f()
+}
+
+// String will create a field with a string value.
+func String(key string, value string) Field {
+	return Field{Key: key, Type: FieldType_String, String: value}
+}
+
+// Int will create a field with an int value.
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 will create a field with an int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: FieldType_Int, Integer: value}
+}
+
+// Uint64 will create a field with a uint64 value.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: FieldType_Uint, Integer: int64(value)}
+}
+
+// Float64 will create a field with a float64 value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: FieldType_Float, Integer: int64(math.Float64bits(value))}
+}
+
+// Bool will create a field with a bool value.
+func Bool(key string, value bool) Field {
+	f := Field{Key: key, Type: FieldType_Bool}
+	if value {
+		f.Integer = 1
+	}
+	return f
+}
+
+// Duration will create a field with a time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: FieldType_Duration, Integer: int64(value)}
+}
+
+// Time will create a field with a time.Time value. The time is stored as it is,
+// since not every time can be represented as nanoseconds since 1970 in an
+// int64.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Type: FieldType_Time, Value: value}
+}
+
+// Err will create a field with the key "error" for the error provided. If the
+// error is nil then the field is excluded from the entry.
+//
+// The JSONFormatter writes the message of the error along with its type in
+// error_type. If the error wraps other errors, via Unwrap or Cause, then every
+// error in the chain is written to error_chain and the type of the root cause
+// to error_kind. Errors that have a stack trace when formatted with %+v, like
+// those from github.com/pkg/errors, have it written to error_stack. The
+// TextFormatter writes the stack trace on the lines below the entry.
+func Err(err error) Field {
+	if err == nil {
+		return Any("error", nil)
+	}
+	return Field{Key: "error", Type: FieldType_Error, Value: err}
+}
+
+// Any will create a field for any value, the value will be stored using the
+// most specific type possible. A nil value will exclude the field from the
+// entry, or remove an existing field with the same key when used with With.
+func Any(key string, value interface{}) Field {
+	switch val := value.(type) {
+	case string:
+		return String(key, val)
+	case int:
+		return Int(key, val)
+	case int8:
+		return Int64(key, int64(val))
+	case int16:
+		return Int64(key, int64(val))
+	case int32:
+		return Int64(key, int64(val))
+	case int64:
+		return Int64(key, val)
+	case uint:
+		return Uint64(key, uint64(val))
+	case uint8:
+		return Uint64(key, uint64(val))
+	case uint16:
+		return Uint64(key, uint64(val))
+	case uint32:
+		return Uint64(key, uint64(val))
+	case uint64:
+		return Uint64(key, val)
+	case float32:
+		return Float64(key, float64(val))
+	case float64:
+		return Float64(key, val)
+	case bool:
+		return Bool(key, val)
+	case time.Duration:
+		return Duration(key, val)
+	case time.Time:
+		return Time(key, val)
+	case error:
+		return Field{Key: key, Type: FieldType_Error, Value: val}
+	default:
+		return Field{Key: key, Type: FieldType_Any, Value: value}
+	}
+}
+
+// Interface will return the value of the field boxed in an interface. This is
+// meant for custom formatters, the built in formatters write each type of field
+// directly.
+func (f Field) Interface() interface{} {
+	switch f.Type {
+	case FieldType_String:
+		return f.String
+	case FieldType_Int:
+		return f.Integer
+	case FieldType_Uint:
+		return uint64(f.Integer)
+	case FieldType_Float:
+		return math.Float64frombits(uint64(f.Integer))
+	case FieldType_Bool:
+		return f.Integer == 1
+	case FieldType_Duration:
+		return time.Duration(f.Integer)
+	case FieldType_Time:
+		return f.time()
+	default:
+		return f.Value
+	}
+}
+
+func (f Field) time() time.Time {
+	t, _ := f.Value.(time.Time)
+	return t
+}
+
+// isNil will return true if the field does not have a value, these fields are
+// not written and will remove existing fields with the same key.
+func (f Field) isNil() bool {
+	return f.Type == FieldType_Any && f.Value == nil
+}
+
+// resolve will return the field with the value of a Valuer in place of the
+// Valuer itself. Fields that do not contain a Valuer are returned as is. The
+// value returned by the Valuer is not resolved again.
+func (f Field) resolve() Field {
+	if f.Type != FieldType_Any {
+		return f
+	}
+	if v, ok := f.Value.(Valuer); ok {
+		return Any(f.Key, v.Value())
+	}
+	return f
+}
+
+// keysToFields will convert the keys provided into fields. Maps do not have an
+// order so the fields are sorted by their key to keep the output stable.
+func keysToFields(keys Keys) []Field {
+	return appendKeys(make([]Field, 0, len(keys)), keys)
+}
+
+// appendKeys will convert the keys provided into fields sorted by their key and
+// append them to the fields provided.
+func appendKeys(fields []Field, keys Keys) []Field {
+	start := len(fields)
+	for k, v := range keys {
+		fields = append(fields, Any(k, v))
+	}
+	sortFields(fields[start:])
+	return fields
+}
+
+// sortFields will sort the fields alphabetically by their key. This is an
+// insertion sort since there are usually only a few fields and it does not need
+// to allocate.
+func sortFields(fields []Field) {
+	for i := 1; i < len(fields); i++ {
+		for j := i; j > 0 && fields[j].Key < fields[j-1].Key; j-- {
+			fields[j], fields[j-1] = fields[j-1], fields[j]
+		}
+	}
+}
+
+// setFields will add the fields provided to the end of the existing fields. If
+// a field already exists then its value is replaced but it keeps its position,
+// and if the new value is nil then the field is removed instead.
+func setFields(existing []Field, fields ...Field) []Field {
+	for _, field := range fields {
+		i := indexOfField(existing, field.Key)
+		switch {
+		case i < 0 && field.isNil():
+		case i < 0:
+			existing = append(existing, field)
+		case field.isNil():
+			existing = append(existing[:i], existing[i+1:]...)
+		default:
+			existing[i] = field
+		}
+	}
+	return existing
+}
+
+func indexOfField(fields []Field, key string) int {
+	for i, field := range fields {
+		if field.Key == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,169 @@
+package timber
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestSetFields(t *testing.T) {
+	fields := setFields(nil,
+		Int("b", 1),
+		Int("a", 2),
+		Any("removed", nil),
+	)
+	assert.Equal(t, []Field{Int("b", 1), Int("a", 2)}, fields)
+
+	fields = setFields(fields, Int("c", 3), Int("b", 4))
+	assert.Equal(t, []Field{Int("b", 4), Int("a", 2), Int("c", 3)}, fields)
+
+	fields = setFields(fields, Any("a", nil))
+	assert.Equal(t, []Field{Int("b", 4), Int("c", 3)}, fields)
+}
+
+func TestAny(t *testing.T) {
+	tm := time.Date(2019, 5, 1, 12, 0, 0, 0, time.FixedZone("test", 3600))
+	err := errors.New("bad")
+	for _, item := range []struct {
+		value     interface{}
+		fieldType FieldType
+		expected  interface{}
+	}{
+		{"test", FieldType_String, "test"},
+		{12, FieldType_Int, int64(12)},
+		{int8(-12), FieldType_Int, int64(-12)},
+		{uint32(12), FieldType_Uint, uint64(12)},
+		{uint64(math.MaxUint64), FieldType_Uint, uint64(math.MaxUint64)},
+		{float32(1.5), FieldType_Float, 1.5},
+		{true, FieldType_Bool, true},
+		{false, FieldType_Bool, false},
+		{time.Second, FieldType_Duration, time.Second},
+		{err, FieldType_Error, err},
+		{[]int{1}, FieldType_Any, []int{1}},
+		{nil, FieldType_Any, nil},
+	} {
+		field := Any("key", item.value)
+		assert.Equal(t, "key", field.Key)
+		assert.Equal(t, item.fieldType, field.Type)
+		assert.Equal(t, item.expected, field.Interface())
+	}
+
+	field := Any("key", tm)
+	assert.Equal(t, FieldType_Time, field.Type)
+	assert.True(t, tm.Equal(field.Interface().(time.Time)))
+	assert.Equal(t, tm.Location(), field.Interface().(time.Time).Location())
+}
+
+func TestTime(t *testing.T) {
+	for _, tm := range []time.Time{
+		{},
+		time.Date(3000, 1, 2, 3, 4, 5, 6, time.UTC),
+		time.Date(1066, 10, 14, 9, 0, 0, 0, time.UTC),
+	} {
+		field := Time("key", tm)
+		assert.True(t, tm.Equal(field.Interface().(time.Time)), tm.String())
+
+		buf := bytes.NewBuffer(nil)
+		writeTextField(buf, field)
+		assert.Equal(t, tm.Format(time.RFC3339Nano), buf.String())
+
+		buf.Reset()
+		writeJSONField(buf, Any("key", tm))
+		assert.Equal(t, `"`+tm.Format(time.RFC3339Nano)+`"`, buf.String())
+	}
+
+	SetLevel(Level_Trace)
+	buf := bytes.NewBuffer(nil)
+	New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true}).
+		InfoEx(Keys{"deadline": time.Time{}}, "test")
+	assert.Contains(t, buf.String(), "deadline: 0001-01-01T00:00:00Z")
+}
+
+func TestErr(t *testing.T) {
+	assert.Equal(t, Field{Key: "error", Type: FieldType_Error, Value: errors.New("bad")}, Err(errors.New("bad")))
+	assert.True(t, Err(nil).isNil())
+}
+
+func TestFieldOrder(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf := bytes.NewBuffer(nil)
+	log := New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true}).
+		With(Keys{"request": 1}).
+		With(Keys{"user": 2, "session": 3}).
+		With(Keys{"request": 4, "removed": nil})
+
+	for i := 0; i < 10; i++ {
+		log.InfoEx(Keys{"user": 5, "b": 6, "a": 7}, "test")
+	}
+	for _, line := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte{'\n'}) {
+		assert.Contains(t, string(line), "{ a: 7, b: 6, user: 5, request: 4, session: 3 } | test")
+	}
+
+	buf.Reset()
+	log.InfoEx(Keys{"request": nil}, "test")
+	assert.Contains(t, buf.String(), "{ session: 3, user: 2 } | test")
+
+	buf.Reset()
+	log.Infow("test", String("z", "first"), Any("user", nil), Int("request", 8))
+	assert.Contains(t, buf.String(), "{ z: first, request: 8, session: 3 } | test")
+}
+
+func TestLogger_WithFields(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf := bytes.NewBuffer(nil)
+	tm := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	log := New().SetOutput(buf).SetFormatter(&JSONFormatter{}).WithFields(
+		String("string", "value"),
+		Int("int", -1),
+		Uint64("uint", 1),
+		Float64("float", 1.5),
+		Bool("bool", true),
+		Duration("duration", time.Second),
+		Time("time_field", tm),
+	)
+	log.Warningw("test", Err(errors.New("bad")), Any("any", []int{1, 2}))
+
+	var obj map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+	assert.Equal(t, "Warning", obj["level"])
+	assert.Equal(t, "test", obj["msg"])
+	assert.Equal(t, "value", obj["string"])
+	assert.Equal(t, float64(-1), obj["int"])
+	assert.Equal(t, float64(1), obj["uint"])
+	assert.Equal(t, 1.5, obj["float"])
+	assert.Equal(t, true, obj["bool"])
+	assert.Equal(t, "1s", obj["duration"])
+	assert.Equal(t, "2019-05-01T12:00:00Z", obj["time_field"])
+	assert.Equal(t, "bad", obj["error"])
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, obj["any"])
+}
+
+func TestLazy(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	calls := 0
+	expensive := Lazy(func() interface{} {
+		calls++
+		return "plan"
+	})
+	log := New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true}).
+		SetLevel(Level_Debug).
+		With(Keys{"inherited": expensive})
+
+	log.TraceEx(Keys{"plan": expensive}, "skipped")
+	log.Tracew("skipped", Any("plan", expensive))
+	assert.Equal(t, 0, calls)
+	assert.Empty(t, buf.String())
+
+	log.DebugEx(Keys{"plan": expensive}, "written")
+	assert.Equal(t, 2, calls)
+	assert.Contains(t, buf.String(), "{ plan: plan, inherited: plan } | written")
+
+	buf.Reset()
+	log.Debugw("nil", Any("inherited", Lazy(func() interface{} { return nil })))
+	assert.Equal(t, " nil\n", buf.String()[buf.Len()-5:])
+	assert.NotContains(t, buf.String(), "inherited")
+}
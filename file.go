@@ -0,0 +1,389 @@
+package timber
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// backupTimeLayout is the time that is added to the name of a file when it
+	// is rotated, it does not contain any characters that are not allowed in
+	// file names on windows.
+	backupTimeLayout = "2006-01-02T15-04-05.000"
+
+	compressSuffix = ".gz"
+)
+
+// FileOptions changes when a FileWriter rotates its file and what is done with
+// the old files.
+type FileOptions struct {
+	// MaxSize is the size in bytes that the file can grow to before it is
+	// rotated. If it is 0 then the file is never rotated because of its size.
+	MaxSize int64
+
+	// RotateEvery is how long a file is written to before it is rotated. If it
+	// is 0 then the file is never rotated because of its age.
+	RotateEvery time.Duration
+
+	// MaxBackups is the number of rotated files that are kept, the oldest files
+	// are removed first. If it is 0 then every rotated file is kept.
+	MaxBackups int
+
+	// MaxAge is how long rotated files are kept for, based on the time they
+	// were rotated. If it is 0 then files are never removed because of their
+	// age.
+	MaxAge time.Duration
+
+	// Compress will gzip rotated files.
+	Compress bool
+
+	// ReopenOnSignal will reopen the file whenever the process receives a
+	// SIGHUP, so that an external tool like logrotate can move the file. This
+	// is not supported on windows.
+	ReopenOnSignal bool
+
+	// Mode is the permissions the file is created with, if it is 0 then 0644 is
+	// used.
+	Mode os.FileMode
+}
+
+// FileWriter is an output that writes entries to a file and rotates it once it
+// gets too big or too old. Rotated files are renamed to include the time they
+// were rotated, so app.log will become app-2019-05-01T12-00-00.000.log.
+//
+//	file, err := timber.NewFileWriter("/var/log/app.log", timber.FileOptions{
+//		MaxSize:    100 << 20,
+//		MaxBackups: 10,
+//		Compress:   true,
+//	})
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer file.Close()
+//	timber.SetOutput(file)
+type FileWriter struct {
+	path    string
+	options FileOptions
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+	closed   bool
+	fileLock sync.Mutex
+
+	// millLock makes sure that only one goroutine is compressing and removing
+	// rotated files at a time, and mills waits for them when the writer is
+	// closed.
+	millLock sync.Mutex
+	mills    sync.WaitGroup
+
+	stopSignals func()
+}
+
+// NewFileWriter will open the file at the path provided for appending,
+// creating it and its directory if they do not exist.
+func NewFileWriter(path string, options FileOptions) (*FileWriter, error) {
+	if options.Mode == 0 {
+		options.Mode = 0644
+	}
+	f := &FileWriter{
+		path:    path,
+		options: options,
+	}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	if options.ReopenOnSignal {
+		f.stopSignals = reopenOnSignal(f)
+	}
+	return f, nil
+}
+
+// open will open the file for appending, the lock must be held by the caller.
+func (f *FileWriter) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, f.options.Mode)
+	if err != nil {
+		return err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	f.file, f.size, f.openedAt = file, stat.Size(), now()
+	return nil
+}
+
+// Write will write the bytes provided to the file, rotating the file first if
+// writing them would make it too big or if it is too old.
+func (f *FileWriter) Write(p []byte) (int, error) {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	if err := f.ensureOpen(); err != nil {
+		return 0, err
+	}
+	if f.shouldRotate(int64(len(p))) {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// shouldRotate will return true if the file needs to be rotated before n bytes
+// are written to it. A file that is empty is never rotated.
+func (f *FileWriter) shouldRotate(n int64) bool {
+	if f.size == 0 {
+		return false
+	}
+	if f.options.MaxSize > 0 && f.size+n > f.options.MaxSize {
+		return true
+	}
+	return f.options.RotateEvery > 0 && now().Sub(f.openedAt) >= f.options.RotateEvery
+}
+
+// Rotate will close the current file, rename it to include the current time and
+// then open a new file in its place.
+func (f *FileWriter) Rotate() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	if err := f.ensureOpen(); err != nil {
+		return err
+	}
+	return f.rotate()
+}
+
+// ensureOpen will open the file again if it could not be opened after being
+// rotated or reopened, so that the writer recovers once the problem is fixed.
+// The lock must be held by the caller.
+func (f *FileWriter) ensureOpen() error {
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file == nil {
+		return f.open()
+	}
+	return nil
+}
+
+// rotate will close the file, rename it and open a new one, the lock must be
+// held by the caller. The file has to be closed before it is renamed since
+// open files cannot be renamed on Windows. If renaming the file fails then the
+// original file is opened again so that entries are not lost, and if opening
+// the new file fails then it is retried by the next write.
+func (f *FileWriter) rotate() error {
+	err := f.file.Close()
+	f.file = nil
+	if err != nil {
+		return err
+	}
+	rotatedAt := now()
+	if err := os.Rename(f.path, f.backupName(rotatedAt)); err != nil && !os.IsNotExist(err) {
+		_ = f.open()
+		return err
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+	f.mills.Add(1)
+	go f.mill(rotatedAt)
+	return nil
+}
+
+// Reopen will close the file and open it again at the same path. This is meant
+// to be used after the file has been moved by another program.
+func (f *FileWriter) Reopen() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	if f.closed {
+		return os.ErrClosed
+	}
+	if f.file != nil {
+		err := f.file.Close()
+		f.file = nil
+		if err != nil {
+			return err
+		}
+	}
+	return f.open()
+}
+
+// Sync will commit the contents of the file to disk.
+func (f *FileWriter) Sync() error {
+	f.fileLock.Lock()
+	defer f.fileLock.Unlock()
+	if err := f.ensureOpen(); err != nil {
+		return err
+	}
+	return f.file.Sync()
+}
+
+// Close will close the file and wait for any rotated files to be compressed and
+// removed. Writes after Close will fail, and the writer is no longer synced by
+// Sync.
+func (f *FileWriter) Close() error {
+	if f.stopSignals != nil {
+		f.stopSignals()
+	}
+	f.fileLock.Lock()
+	var err error
+	if f.file != nil {
+		err = f.file.Close()
+		f.file = nil
+	}
+	f.closed = true
+	f.fileLock.Unlock()
+	f.mills.Wait()
+	forgetSink(f)
+	return err
+}
+
+// backupName will return the name a file rotated at the time provided will be
+// renamed to. If a file with that name already exists then a counter is added.
+func (f *FileWriter) backupName(t time.Time) string {
+	dir, prefix, ext := f.nameParts()
+	name := prefix + t.UTC().Format(backupTimeLayout)
+	for i := 0; ; i++ {
+		path := filepath.Join(dir, name+ext)
+		if i > 0 {
+			path = filepath.Join(dir, name+"-"+strconv.Itoa(i)+ext)
+		}
+		if !exists(path) && !exists(path+compressSuffix) {
+			return path
+		}
+	}
+}
+
+// nameParts will split the path of the file into its directory, the prefix of
+// its rotated files and its extension.
+func (f *FileWriter) nameParts() (dir, prefix, ext string) {
+	dir, name := filepath.Split(f.path)
+	ext = filepath.Ext(name)
+	return dir, strings.TrimSuffix(name, ext) + "-", ext
+}
+
+type backup struct {
+	path    string
+	time    time.Time
+	counter int
+}
+
+// backups will return every rotated file for this writer sorted from newest to
+// oldest.
+func (f *FileWriter) backups() ([]backup, error) {
+	dir, prefix, ext := f.nameParts()
+	infos, err := ioutil.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return nil, err
+	}
+	backups := make([]backup, 0, len(infos))
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), compressSuffix)
+		if !strings.HasSuffix(stamp, ext) {
+			continue
+		}
+		stamp = strings.TrimSuffix(stamp, ext)
+		if len(stamp) < len(backupTimeLayout) {
+			continue
+		}
+		t, err := time.Parse(backupTimeLayout, stamp[:len(backupTimeLayout)])
+		if err != nil {
+			continue
+		}
+		counter := 0
+		if rest := stamp[len(backupTimeLayout):]; len(rest) > 0 {
+			if counter, err = strconv.Atoi(strings.TrimPrefix(rest, "-")); err != nil || rest[0] != '-' {
+				continue
+			}
+		}
+		backups = append(backups, backup{
+			path:    filepath.Join(dir, name),
+			time:    t,
+			counter: counter,
+		})
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		if backups[i].time.Equal(backups[j].time) {
+			return backups[i].counter > backups[j].counter
+		}
+		return backups[i].time.After(backups[j].time)
+	})
+	return backups, nil
+}
+
+// mill will remove rotated files that are past the limits of the writer as of
+// the time provided and compress the rest.
+func (f *FileWriter) mill(t time.Time) {
+	defer f.mills.Done()
+	f.millLock.Lock()
+	defer f.millLock.Unlock()
+	backups, err := f.backups()
+	if err != nil {
+		return
+	}
+	cutoff := t.Add(-f.options.MaxAge)
+	for i, b := range backups {
+		if (f.options.MaxBackups > 0 && i >= f.options.MaxBackups) ||
+			(f.options.MaxAge > 0 && b.time.Before(cutoff)) {
+			_ = os.Remove(b.path)
+			continue
+		}
+		if f.options.Compress && !strings.HasSuffix(b.path, compressSuffix) {
+			_ = compress(b.path, f.options.Mode)
+		}
+	}
+}
+
+// compress will gzip the file at the path provided and then remove it.
+func compress(path string, mode os.FileMode) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+compressSuffix, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(path + compressSuffix)
+		}
+	}()
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		_ = dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	_ = src.Close()
+	return os.Remove(path)
+}
+
+func exists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
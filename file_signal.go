@@ -0,0 +1,38 @@
+//go:build !windows
+// +build !windows
+
+package timber
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+// reopenOnSignal will reopen the file whenever the process receives a SIGHUP.
+// The function returned will stop listening for the signal.
+func reopenOnSignal(f *FileWriter) func() {
+	signals, done := make(chan os.Signal, 1), make(chan struct{})
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-signals:
+				if err := f.Reopen(); err != nil {
+					fmt.Fprintf(os.Stderr, "timber: failed to reopen %s: %v\n", f.path, err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
@@ -0,0 +1,33 @@
+//go:build !windows
+// +build !windows
+
+package timber
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFileWriter_ReopenOnSignal(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	path := filepath.Join(dir, "app.log")
+	f, err := NewFileWriter(path, FileOptions{ReopenOnSignal: true})
+	assert.NoError(t, err)
+	defer f.Close()
+
+	assert.NoError(t, os.Rename(path, path+".1"))
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	deadline := time.Now().Add(time.Second)
+	for !exists(path) && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	_, err = f.Write([]byte("after\n"))
+	assert.NoError(t, err)
+	assert.NoError(t, f.Close())
+	assert.Equal(t, "after\n", readFile(t, path))
+}
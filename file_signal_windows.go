@@ -0,0 +1,9 @@
+//go:build windows
+// +build windows
+
+package timber
+
+// reopenOnSignal does nothing on windows since there is no SIGHUP.
+func reopenOnSignal(f *FileWriter) func() {
+	return func() {}
+}
@@ -0,0 +1,194 @@
+package timber
+
+import (
+	"compress/gzip"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+// tempDir will create a temporary directory for a test, the function returned
+// will remove it.
+func tempDir(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "timber")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return dir, func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func readDir(t *testing.T, dir string) []string {
+	infos, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func readFile(t *testing.T, path string) string {
+	data, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+func TestFileWriter(t *testing.T) {
+	clock := &fixedClock{now: time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)}
+	SetClock(clock)
+	defer SetClock(nil)
+
+	t.Run("append", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		path := filepath.Join(dir, "logs", "app.log")
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "existing.log"), nil, 0644))
+
+		f, err := NewFileWriter(path, FileOptions{})
+		assert.NoError(t, err)
+		_, err = f.Write([]byte("first\n"))
+		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
+
+		f, err = NewFileWriter(path, FileOptions{})
+		assert.NoError(t, err)
+		New().SetOutput(f).SetColor(false).Info("second")
+		assert.NoError(t, f.Close())
+
+		_, err = f.Write([]byte("closed\n"))
+		assert.Equal(t, os.ErrClosed, err)
+		assert.Equal(t, os.ErrClosed, f.Sync())
+		assert.NoError(t, Sync(), "closed writers are not synced")
+		assert.Contains(t, readFile(t, path), "first\n")
+		assert.Contains(t, readFile(t, path), "second\n")
+	})
+
+	t.Run("max size", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		f, err := NewFileWriter(filepath.Join(dir, "app.log"), FileOptions{MaxSize: 10})
+		assert.NoError(t, err)
+		for _, line := range []string{"aaaa\n", "bbbb\n", "cccc\n", "dddddddddddddddd\n"} {
+			_, err = f.Write([]byte(line))
+			assert.NoError(t, err)
+		}
+		assert.NoError(t, f.Close())
+		assert.Equal(t, []string{
+			"app-2019-05-01T12-00-00.000-1.log",
+			"app-2019-05-01T12-00-00.000.log",
+			"app.log",
+		}, readDir(t, dir))
+		assert.Equal(t, "aaaa\nbbbb\n", readFile(t, filepath.Join(dir, "app-2019-05-01T12-00-00.000.log")))
+		assert.Equal(t, "cccc\n", readFile(t, filepath.Join(dir, "app-2019-05-01T12-00-00.000-1.log")))
+		assert.Equal(t, "dddddddddddddddd\n", readFile(t, filepath.Join(dir, "app.log")))
+	})
+
+	t.Run("rotate every", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		f, err := NewFileWriter(filepath.Join(dir, "app.log"), FileOptions{RotateEvery: time.Hour})
+		assert.NoError(t, err)
+		_, _ = f.Write([]byte("first\n"))
+		clock.now = clock.now.Add(59 * time.Minute)
+		_, _ = f.Write([]byte("second\n"))
+		clock.now = clock.now.Add(time.Minute)
+		_, _ = f.Write([]byte("third\n"))
+		assert.NoError(t, f.Close())
+		assert.Equal(t, []string{"app-2019-05-01T13-00-00.000.log", "app.log"}, readDir(t, dir))
+		assert.Equal(t, "first\nsecond\n", readFile(t, filepath.Join(dir, "app-2019-05-01T13-00-00.000.log")))
+		assert.Equal(t, "third\n", readFile(t, filepath.Join(dir, "app.log")))
+	})
+
+	t.Run("max backups and age", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		f, err := NewFileWriter(filepath.Join(dir, "app"), FileOptions{MaxBackups: 2, MaxAge: 3 * time.Hour})
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app-not-a-backup"), nil, 0644))
+		for i := 0; i < 4; i++ {
+			clock.now = clock.now.Add(time.Hour)
+			_, _ = f.Write([]byte("entry\n"))
+			assert.NoError(t, f.Rotate())
+		}
+		assert.NoError(t, f.Close())
+		assert.Equal(t, []string{
+			"app",
+			"app-2019-05-01T16-00-00.000",
+			"app-2019-05-01T17-00-00.000",
+			"app-not-a-backup",
+		}, readDir(t, dir))
+
+		f, err = NewFileWriter(filepath.Join(dir, "app"), FileOptions{MaxAge: 30 * time.Minute})
+		assert.NoError(t, err)
+		clock.now = clock.now.Add(time.Hour)
+		assert.NoError(t, f.Rotate())
+		assert.NoError(t, f.Close())
+		assert.Equal(t, []string{"app", "app-2019-05-01T18-00-00.000", "app-not-a-backup"}, readDir(t, dir))
+	})
+
+	t.Run("compress", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		f, err := NewFileWriter(filepath.Join(dir, "app.log"), FileOptions{Compress: true})
+		assert.NoError(t, err)
+		_, _ = f.Write([]byte("compressed\n"))
+		assert.NoError(t, f.Rotate())
+		assert.NoError(t, f.Close())
+
+		names := readDir(t, dir)
+		assert.Equal(t, []string{"app-" + clock.now.Format(backupTimeLayout) + ".log.gz", "app.log"}, names)
+		file, err := os.Open(filepath.Join(dir, names[0]))
+		assert.NoError(t, err)
+		defer file.Close()
+		gz, err := gzip.NewReader(file)
+		assert.NoError(t, err)
+		data, err := ioutil.ReadAll(gz)
+		assert.NoError(t, err)
+		assert.Equal(t, "compressed\n", string(data))
+	})
+
+	t.Run("reopen", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		path := filepath.Join(dir, "app.log")
+		f, err := NewFileWriter(path, FileOptions{})
+		assert.NoError(t, err)
+		_, _ = f.Write([]byte("before\n"))
+		assert.NoError(t, os.Rename(path, path+".1"))
+		assert.NoError(t, f.Reopen())
+		_, _ = f.Write([]byte("after\n"))
+		assert.NoError(t, f.Sync())
+		assert.NoError(t, f.Close())
+		assert.Equal(t, "before\n", readFile(t, path+".1"))
+		assert.Equal(t, "after\n", readFile(t, path))
+	})
+
+	t.Run("recover after failed rotate", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		logs := filepath.Join(dir, "logs")
+		f, err := NewFileWriter(filepath.Join(logs, "app.log"), FileOptions{})
+		assert.NoError(t, err)
+		defer f.Close()
+		_, _ = f.Write([]byte("before\n"))
+
+		// Replacing the directory with a file makes renaming and opening fail.
+		assert.NoError(t, os.RemoveAll(logs))
+		assert.NoError(t, ioutil.WriteFile(logs, nil, 0644))
+		assert.Error(t, f.Rotate())
+		_, err = f.Write([]byte("lost\n"))
+		assert.Error(t, err)
+
+		assert.NoError(t, os.Remove(logs))
+		_, err = f.Write([]byte("after\n"))
+		assert.NoError(t, err)
+		assert.Equal(t, "after\n", readFile(t, filepath.Join(logs, "app.log")))
+	})
+}
@@ -0,0 +1,89 @@
+package timber
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+var (
+	formatter     Formatter = &TextFormatter{}
+	formatterLock sync.RWMutex
+)
+
+// Entry is a single message that is being written to the log. It is built by
+// the logger once the level has been checked and then handed to a Formatter to
+// be rendered.
+type Entry struct {
+	// Level is the severity of the message.
+	Level Level
+
+	// Time is when the entry was created.
+	Time time.Time
+
+	// Caller is the file and line number of the code that wrote the entry, it
+	// will be blank if the caller is not captured, see SetCaller.
+	Caller string
+
+	// Function is the name of the function that wrote the entry, like
+	// "server.(*Handler).ServeHTTP". It is only captured if it was enabled via
+	// SetCaller.
+	Function string
+
+	// Name is the dotted name of the logger that wrote the entry, it will be
+	// blank if the logger was not created via Named.
+	Name string
+
+	// Prefix is the prefix of the logger that wrote the entry, it will be blank
+	// if the logger does not have a prefix.
+	Prefix string
+
+	// Fields are the keys provided at the call site merged with the keys of the
+	// logger. The keys from the call site are first, followed by the keys of the
+	// logger in the order they were added. Each key only appears once, and keys
+	// with a nil value are excluded.
+	Fields []Field
+
+	// Message is the message that was written without any trailing newline.
+	Message string
+
+	// Stack is the stack of the goroutine that wrote the entry, followed by the
+	// stacks of every other goroutine when StackMode_All is used. It is only
+	// captured for entries at or above the stack level of the logger, see
+	// SetStack.
+	Stack []Goroutine
+
+	// Color is true when the entry is being written to an output that supports
+	// ANSI colors, formatters should not write any escape codes when it is false.
+	Color bool
+}
+
+// Formatter renders entries so that they can be written to the output of a
+// logger. Implementations must write exactly one complete entry to the buffer
+// including any trailing newline, and must be safe to use from multiple
+// goroutines. Entries and buffers are reused once Format returns, so neither
+// can be retained by the formatter.
+type Formatter interface {
+	Format(buf *bytes.Buffer, entry *Entry) error
+}
+
+// SetFormatter will change the formatter that the global logger uses to render
+// entries. Any logger that has not been given its own formatter via
+// Logger.SetFormatter will also start using this formatter. By default this is
+// a TextFormatter.
+func SetFormatter(f Formatter) {
+	if f == nil {
+		f = &TextFormatter{}
+	}
+	formatterLock.Lock()
+	defer formatterLock.Unlock()
+	formatter = f
+}
+
+// GetFormatter will return the formatter that the global logger is currently
+// using.
+func GetFormatter() Formatter {
+	formatterLock.RLock()
+	defer formatterLock.RUnlock()
+	return formatter
+}
@@ -0,0 +1,82 @@
+package timber
+
+import (
+	"bytes"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+type entryFormatter struct {
+	entries []Entry
+}
+
+func (f *entryFormatter) Format(buf *bytes.Buffer, entry *Entry) error {
+	// Entries are reused once they have been formatted, so the fields need to
+	// be copied.
+	e := *entry
+	e.Fields = append([]Field{}, entry.Fields...)
+	f.entries = append(f.entries, e)
+	buf.WriteString(entry.Message + "\n")
+	return nil
+}
+
+type failingFormatter struct{}
+
+func (failingFormatter) Format(buf *bytes.Buffer, entry *Entry) error {
+	return errors.New("cannot format")
+}
+
+func TestLogger_SetFormatter(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("custom", func(t *testing.T) {
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		log := New().SetOutput(buf).SetFormatter(f).Prefix("prefix")
+		log.With(Keys{
+			"things": "stuff",
+			"shared": "inherited",
+			"null":   nil,
+		}).InfoEx(Keys{
+			"shared": "call site",
+		}, "test %d\n", 1)
+
+		assert.Equal(t, "test 1\n", buf.String())
+		if assert.Len(t, f.entries, 1) {
+			entry := f.entries[0]
+			assert.Equal(t, Level_Info, entry.Level)
+			assert.Equal(t, "prefix", entry.Prefix)
+			assert.Equal(t, "test 1", entry.Message)
+			assert.Contains(t, entry.Caller, "formatter_test.go")
+			assert.False(t, entry.Time.IsZero())
+			assert.Equal(t, []Field{
+				String("shared", "call site"),
+				String("things", "stuff"),
+			}, entry.Fields)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		New().SetOutput(buf).SetFormatter(failingFormatter{}).Info("test")
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestSetFormatter(t *testing.T) {
+	defer SetFormatter(nil)
+	defer SetOutput(os.Stdout)
+	SetLevel(Level_Trace)
+
+	buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+	SetOutput(buf)
+	SetFormatter(f)
+	assert.Equal(t, f, GetFormatter())
+	Info("global")
+	New().Info("new")
+	assert.Equal(t, "global\nnew\n", buf.String())
+
+	SetFormatter(nil)
+	assert.IsType(t, &TextFormatter{}, GetFormatter())
+}
@@ -15,6 +15,8 @@ type LevelItem struct {
 	ShortName       string  `json:"shortName"`
 	ForegroundColor *string `json:"foregroundColor"`
 	BackgroundColor *string `json:"backgroundColor"`
+	TerminalAction  string  `json:"terminalAction"`
+	SyslogSeverity  int     `json:"syslogSeverity"`
 }
 
 type Data struct {
@@ -59,15 +61,23 @@ func main() {
 	}
 }
 
-var levelsTemplate = `// Code generated by gen/gen.go - DO NOT EDIT.
+var levelsTemplate = `{{define "terminalActionDoc"}}{{if eq .TerminalAction "Exit"}}
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.{{else if eq .TerminalAction "Panic"}}
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.{{end}}{{end}}// Code generated by gen/gen.go - DO NOT EDIT.
 // This code can be regenerated by running the go generate below.
 //go:generate make generated
 
 package timber
 
 import (
+	"context"
 	"fmt"
 	"github.com/logrusorgru/aurora"
+	"io"
+	"strconv"
+	"strings"
 )
 
 type colorFunc func(arg interface{}) aurora.Value
@@ -96,19 +106,81 @@ var (
 	shortLevelNames = map[Level]string{ {{range .Levels}}
 		Level_{{.Name}}: "{{.ShortName}}",{{end}}
 	}
+
+	terminalActions = map[Level]terminalAction{ {{range .Levels}}{{ if .TerminalAction }}
+		Level_{{.Name}}: terminalAction_{{.TerminalAction}},{{end}}{{end}}
+	}
+
+	syslogSeverities = map[Level]SyslogSeverity{ {{range .Levels}}
+		Level_{{.Name}}: {{.SyslogSeverity}},{{end}}
+	}
 )
 
+// ParseLevel will convert the name, short name or number of a level into a
+// Level, case is ignored. The number 0 is also accepted and means that there is
+// no minimum level.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) { {{range .Levels}}
+	case "{{ToLower .Name}}",{{if ne (ToLower .Name) (ToLower .ShortName)}} "{{ToLower .ShortName}}",{{end}} "{{.Order}}":
+		return Level_{{.Name}}, nil{{end}}
+	case "0":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("timber: unknown level %q", s)
+	}
+}
+
+// String will return the name of the level.
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler. Levels are written using their
+// name, or their number if they do not have a name.
+func (l Level) MarshalText() ([]byte, error) {
+	if name, ok := levelNames[l]; ok {
+		return []byte(name), nil
+	}
+	return []byte(strconv.Itoa(int(l))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Any text that is accepted
+// by ParseLevel can be unmarshalled.
+func (l *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = lvl
+	return nil
+}
+
 type Logger interface { {{range .Levels}}
-	// {{.Name}} writes the provided string to the log.
+	// {{.Name}} writes the provided string to the log.{{template "terminalActionDoc" .}}
 	{{.Name}}(msg interface{})
 
-	// {{.Name}}f writes a formatted string using the arguments provided to the log.
+	// {{.Name}}f writes a formatted string using the arguments provided to the log.{{template "terminalActionDoc" .}}
 	{{.Name}}f(msg string, args ...interface{})
 
 	// {{.Name}}Ex writes a formatted string using the arguments provided to the log
 	// but also will prefix the log message with they keys provided to help print
-	// runtime variables.
+	// runtime variables.{{template "terminalActionDoc" .}}
 	{{.Name}}Ex(keys Keys, msg string, args ...interface{})
+
+	// {{.Name}}w writes the provided string to the log along with the typed fields
+	// provided.{{template "terminalActionDoc" .}}
+	{{.Name}}w(msg string, fields ...Field)
+
+	// {{.Name}}Ctx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.{{template "terminalActionDoc" .}}
+	{{.Name}}Ctx(ctx context.Context, msg string, args ...interface{})
+
+	// {{.Name}}E writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.{{template "terminalActionDoc" .}}
+	{{.Name}}E(err error, msg string, args ...interface{})
 {{end}}
 	// SetDepth will change the number of stacks that will be skipped to find
 	// the filepath and line number of the executed code.
@@ -125,25 +197,109 @@ type Logger interface { {{range .Levels}}
 	// are written with every message.
 	With(keys Keys) Logger
 
+	// WithFields will create a new Logger interface the same way that With does, but
+	// with typed fields instead of keys.
+	WithFields(fields ...Field) Logger
+
 	// Prefix will add a small string before the file path.
 	Prefix(prefix string) Logger
+
+	// SetOutput will change the sink that this logger and any loggers created from
+	// it via With will write to. If the output is nil then the logger will write to
+	// the global output instead.
+	SetOutput(w io.Writer) Logger
+
+	// SetFormatter will change how entries are rendered by this logger and any
+	// loggers created from it via With. If the formatter is nil then the logger
+	// will use the global formatter instead.
+	SetFormatter(f Formatter) Logger
+
+	// SetLevel will set the minimum message level that will be written by this
+	// logger and any loggers created from it via With, overriding the global level.
+	// Setting the level to 0 will make the logger inherit its level again.
+	SetLevel(lvl Level) Logger
+
+	// Level will return the minimum message level that will be written by this
+	// logger. If the level has not been set on this logger or any of the loggers it
+	// was created from then this is the global level.
+	Level() Level
+
+	// Name will return the dotted name of the logger, loggers created via With will
+	// have the same name as the logger they were created from. The global logger
+	// and loggers created via New do not have a name.
+	Name() string
+
+	// SetColor will force ANSI colors to be enabled or disabled for this logger and
+	// any loggers created from it via With. If it is not set then colors are only
+	// used when NO_COLOR is not set and either FORCE_COLOR is set or the output of
+	// the logger is a terminal.
+	SetColor(enabled bool) Logger
+
+	// SetStack will capture stacks for entries at or above the level provided that
+	// are written by this logger and any loggers created from it via With. The mode
+	// decides whether only the goroutine that wrote the entry or every goroutine is
+	// captured. Setting the mode to 0 will make the logger inherit the stack
+	// settings again.
+	SetStack(lvl Level, mode StackMode) Logger
+
+	// SetCaller will change how the caller of each entry is written by this logger
+	// and any loggers created from it via With. If the function is true then the
+	// name of the function that wrote the entry is included. Setting the mode to 0
+	// will make the logger inherit the caller settings again.
+	SetCaller(mode CallerMode, function bool) Logger
 }{{range .Levels}}
 
-// {{.Name}} writes the provided string to the log.
+// {{.Name}} writes the provided string to the log.{{template "terminalActionDoc" .}}
 func (l *logger) {{.Name}}(msg interface{}) {
-	l.log(l.stackDepth, Level_{{.Name}}, nil, msg)
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.log(l.stackDepth, Level_{{.Name}}, nil, nil, msg)
 }
 
-// {{.Name}}f writes a formatted string using the arguments provided to the log.
+// {{.Name}}f writes a formatted string using the arguments provided to the log.{{template "terminalActionDoc" .}}
 func (l *logger) {{.Name}}f(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_{{.Name}}, nil, fmt.Sprintf(msg, args...))
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.log(l.stackDepth, Level_{{.Name}}, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // {{.Name}}Ex writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
-// runtime variables.
+// runtime variables.{{template "terminalActionDoc" .}}
 func (l *logger) {{.Name}}Ex(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_{{.Name}}, keys, fmt.Sprintf(msg, args...))
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.log(l.stackDepth, Level_{{.Name}}, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// {{.Name}}w writes the provided string to the log along with the typed fields
+// provided.{{template "terminalActionDoc" .}}
+func (l *logger) {{.Name}}w(msg string, fields ...Field) {
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.log(l.stackDepth, Level_{{.Name}}, nil, fields, msg)
+}
+
+// {{.Name}}Ctx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.{{template "terminalActionDoc" .}}
+func (l *logger) {{.Name}}Ctx(ctx context.Context, msg string, args ...interface{}) {
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.logCtx(ctx, l.stackDepth, Level_{{.Name}}, fmt.Sprintf(msg, args...))
+}
+
+// {{.Name}}E writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.{{template "terminalActionDoc" .}}
+func (l *logger) {{.Name}}E(err error, msg string, args ...interface{}) {
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.log(l.stackDepth, Level_{{.Name}}, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }{{else}}
 // No levels
 {{end}}
@@ -151,21 +307,64 @@ func (l *logger) {{.Name}}Ex(keys Keys, msg string, args ...interface{}) {
 
 {{range .Levels}}
 
-// {{.Name}} writes the provided string to the log.
+// {{.Name}} writes the provided string to the log.{{template "terminalActionDoc" .}}
 func {{.Name}}(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, nil, msg)
+	{{if ne .TerminalAction "Exit"}}if !defaultLogger.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, nil, nil, msg)
 }
 
-// {{.Name}}f writes a formatted string using the arguments provided to the log.
+// {{.Name}}f writes a formatted string using the arguments provided to the log.{{template "terminalActionDoc" .}}
 func {{.Name}}f(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, nil, fmt.Sprintf(msg, args...))
+	{{if ne .TerminalAction "Exit"}}if !defaultLogger.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // {{.Name}}Ex writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
-// runtime variables.
+// runtime variables.{{template "terminalActionDoc" .}}
 func {{.Name}}Ex(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, keys, fmt.Sprintf(msg, args...))
+	{{if ne .TerminalAction "Exit"}}if !defaultLogger.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// {{.Name}}w writes the provided string to the log along with the typed fields
+// provided.{{template "terminalActionDoc" .}}
+func {{.Name}}w(msg string, fields ...Field) {
+	{{if ne .TerminalAction "Exit"}}if !defaultLogger.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, nil, fields, msg)
+}
+
+// {{.Name}}Ctx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.{{template "terminalActionDoc" .}}
+func {{.Name}}Ctx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.{{.Name}}Ctx(ctx, msg, args...)
+		return
+	}
+	{{if ne .TerminalAction "Exit"}}if !l.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}l.logCtx(ctx, l.stackDepth, Level_{{.Name}}, fmt.Sprintf(msg, args...))
+}
+
+// {{.Name}}E writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.{{template "terminalActionDoc" .}}
+func {{.Name}}E(err error, msg string, args ...interface{}) {
+	{{if ne .TerminalAction "Exit"}}if !defaultLogger.shouldLog(Level_{{.Name}}) {
+		return
+	}
+	{{end}}defaultLogger.log(defaultLogger.stackDepth, Level_{{.Name}}, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }{{else}}
 // No levels
 {{end}}
@@ -178,37 +377,93 @@ var testTemplate = `// Code generated by gen/gen.go - DO NOT EDIT.
 package timber
 
 import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 {{range .Levels}}
 
+func TestParseLevel_{{.Name}}(t *testing.T) {
+	for _, s := range []string{"{{.Name}}", "{{ToLower .Name}}", "{{.ShortName}}", "{{ToLower .ShortName}}", "{{.Order}}"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_{{.Name}}, lvl)
+	}
+}
+
+func TestLevel_{{.Name}}_Text(t *testing.T) {
+	assert.Equal(t, "{{.Name}}", Level_{{.Name}}.String())
+	text, err := Level_{{.Name}}.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_{{.Name}}, lvl)
+}
+
 func Test{{.Name}}(t *testing.T) {
-	{{.Name}}("test")
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}{{.Name}}("test")
 }
 
 func Test{{.Name}}f(t *testing.T) {
-	{{.Name}}f("test %s", "format")
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}{{.Name}}f("test %s", "format")
 }
 
 func Test{{.Name}}Ex(t *testing.T) {
-	{{.Name}}Ex(map[string]interface{}{
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}{{.Name}}Ex(map[string]interface{}{
 		"thing": "stuff",
 	}, "test")
 }
 
 func TestLogger_{{.Name}}(t *testing.T) {
-	New().{{.Name}}("test")
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}New().{{.Name}}("test")
 }
 
 func TestLogger_{{.Name}}f(t *testing.T) {
-	New().{{.Name}}f("test %s", "format")
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}New().{{.Name}}f("test %s", "format")
 }
 
 func TestLogger_{{.Name}}Ex(t *testing.T) {
-	New().{{.Name}}Ex(map[string]interface{}{
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}New().{{.Name}}Ex(map[string]interface{}{
 		"thing": "stuff",
 	}, "test")
 }
+
+func Test{{.Name}}w(t *testing.T) {
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}{{.Name}}w("test", String("thing", "stuff"))
+}
+
+func TestLogger_{{.Name}}w(t *testing.T) {
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}New().{{.Name}}w("test", String("thing", "stuff"))
+}
+
+func Test{{.Name}}Ctx(t *testing.T) {
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}{{.Name}}Ctx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_{{.Name}}Ctx(t *testing.T) {
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}New().{{.Name}}Ctx(context.Background(), "test %s", "format")
+}
+
+func Test{{.Name}}E(t *testing.T) {
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}{{.Name}}E(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_{{.Name}}E(t *testing.T) {
+	{{if eq .TerminalAction "Exit"}}defer expectExit(t)()
+	{{end}}New().{{.Name}}E(errors.New("failed"), "test %s", "format")
+}
 {{else}}
 // No levels
 {{end}}`
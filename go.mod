@@ -1,8 +1,9 @@
 module github.com/elliotcourant/timber
 
-go 1.12
+go 1.14
 
 require (
 	github.com/logrusorgru/aurora v0.0.0-20190428105938-cea283e61946
 	github.com/stretchr/testify v1.3.0
+	golang.org/x/term v0.0.0-20201126162022-7de9c90e9dd1
 )
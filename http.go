@@ -0,0 +1,125 @@
+package timber
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// LevelHandler will return an http.Handler that can be used to read and change
+// logging levels at runtime.
+//
+// A GET request will respond with the global level and the level of every named
+// logger as JSON:
+//
+//	{"level":"Info","loggers":{"db":"Warning","db.pool":"Warning"}}
+//
+// A PUT request will change a level and then respond the same way as a GET. The
+// body must be a JSON object with a level, which can be the name, short name or
+// number of the level. If a logger is also provided then the level of that named
+// logger is changed, otherwise the global level is changed. Only loggers that
+// have already been created via Named can be changed, any other name will
+// respond with a 404:
+//
+//	{"logger":"db","level":"warning"}
+//
+// Setting a named logger to level 0 will make it inherit its level again.
+func LevelHandler() http.Handler {
+	return levelHandler{}
+}
+
+type levelHandler struct{}
+
+type levelState struct {
+	Level   Level            `json:"level"`
+	Loggers map[string]Level `json:"loggers"`
+}
+
+type levelRequest struct {
+	Logger string          `json:"logger"`
+	Level  json.RawMessage `json:"level"`
+}
+
+func (h levelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if status, err := h.update(r); err != nil {
+			h.writeJSON(w, status, map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+	default:
+		w.Header().Set("Allow", strings.Join([]string{http.MethodGet, http.MethodPut}, ", "))
+		h.writeJSON(w, http.StatusMethodNotAllowed, map[string]string{
+			"error": fmt.Sprintf("method %s is not allowed", r.Method),
+		})
+		return
+	}
+	h.writeJSON(w, http.StatusOK, h.state())
+}
+
+// update will change the level requested, if the request cannot be applied
+// then the status code to respond with is returned along with the error.
+func (h levelHandler) update(r *http.Request) (int, error) {
+	var req levelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return http.StatusBadRequest, fmt.Errorf("invalid request body: %v", err)
+	}
+	if len(req.Level) == 0 {
+		return http.StatusBadRequest, fmt.Errorf("a level must be provided")
+	}
+	// The level can either be a JSON string or a JSON number, if it is a string
+	// then remove the quotes so it can be parsed the same way.
+	raw := string(req.Level)
+	if strings.HasPrefix(raw, `"`) {
+		if err := json.Unmarshal(req.Level, &raw); err != nil {
+			return http.StatusBadRequest, fmt.Errorf("invalid level: %v", err)
+		}
+	}
+	lvl, err := ParseLevel(raw)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	name := normalizeName(req.Logger)
+	if len(name) == 0 {
+		SetLevel(lvl)
+		return http.StatusOK, nil
+	}
+	// Only loggers that the program has created can be changed, so that a typo
+	// is reported instead of creating a logger that nothing writes to.
+	namedLoggersLock.Lock()
+	lg, ok := namedLoggers[name]
+	namedLoggersLock.Unlock()
+	if !ok {
+		return http.StatusNotFound, fmt.Errorf("logger %q does not exist", name)
+	}
+	lg.SetLevel(lvl)
+	return http.StatusOK, nil
+}
+
+func (h levelHandler) state() levelState {
+	namedLoggersLock.Lock()
+	names := make([]string, 0, len(namedLoggers))
+	for name := range namedLoggers {
+		names = append(names, name)
+	}
+	namedLoggersLock.Unlock()
+
+	state := levelState{
+		Level:   GetLevel(),
+		Loggers: make(map[string]Level, len(names)),
+	}
+	for _, name := range names {
+		state.Loggers[name] = getNamedLogger(name).Level()
+	}
+	return state
+}
+
+func (h levelHandler) writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
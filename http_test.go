@@ -0,0 +1,90 @@
+package timber
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	defer SetLevel(Level_Trace)
+	defer Named("test_http").SetLevel(0)
+	SetLevel(Level_Info)
+	Named("test_http.child")
+
+	server := httptest.NewServer(LevelHandler())
+	defer server.Close()
+
+	do := func(t *testing.T, method, body string) (int, map[string]interface{}) {
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(body))
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if !assert.NoError(t, err) {
+			t.FailNow()
+		}
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		result := map[string]interface{}{}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+		return resp.StatusCode, result
+	}
+
+	t.Run("get", func(t *testing.T) {
+		status, result := do(t, http.MethodGet, "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Info", result["level"])
+		assert.Equal(t, "Info", result["loggers"].(map[string]interface{})["test_http.child"])
+	})
+
+	t.Run("put global by number", func(t *testing.T) {
+		status, result := do(t, http.MethodPut, `{"level": 5}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Warning", result["level"])
+		assert.Equal(t, Level_Warning, GetLevel())
+	})
+
+	t.Run("put logger by name", func(t *testing.T) {
+		status, result := do(t, http.MethodPut, `{"logger": "test_http", "level": "trce"}`)
+		assert.Equal(t, http.StatusOK, status)
+		loggers := result["loggers"].(map[string]interface{})
+		assert.Equal(t, "Trace", loggers["test_http"])
+		assert.Equal(t, "Trace", loggers["test_http.child"])
+		assert.Equal(t, "Warning", result["level"])
+		assert.Equal(t, Level_Trace, Named("test_http.child").Level())
+	})
+
+	t.Run("put numeric string", func(t *testing.T) {
+		status, _ := do(t, http.MethodPut, `{"level": "6"}`)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, Level_Error, GetLevel())
+	})
+
+	t.Run("bad level", func(t *testing.T) {
+		for _, body := range []string{`{"level": "loud"}`, `{"level": 42}`, `{}`, `not json`} {
+			status, result := do(t, http.MethodPut, body)
+			assert.Equal(t, http.StatusBadRequest, status, body)
+			assert.NotEmpty(t, result["error"], body)
+		}
+		assert.Equal(t, Level_Error, GetLevel())
+	})
+
+	t.Run("unknown logger", func(t *testing.T) {
+		status, result := do(t, http.MethodPut, `{"logger": "test_http.missing", "level": "info"}`)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.NotEmpty(t, result["error"])
+		namedLoggersLock.Lock()
+		_, ok := namedLoggers["test_http.missing"]
+		namedLoggersLock.Unlock()
+		assert.False(t, ok, "the logger should not be created")
+	})
+
+	t.Run("bad method", func(t *testing.T) {
+		status, _ := do(t, http.MethodPost, `{"level": "info"}`)
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+}
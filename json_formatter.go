@@ -0,0 +1,114 @@
+package timber
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+// JSONFormatter renders each entry as a single JSON object followed by a
+// newline. The fields of the entry are written at the top level of the object
+// in the order they are in the entry. The caller is left out if it was not
+// captured, and the function that wrote the entry is included if it was, see
+// SetCaller. Error fields are followed by the type, cause chain and stack trace
+// of the error, see Err. A captured stack is written as an array of frames, see
+// SetStack. A field with the same key as one of these or as the level, time,
+// logger, prefix or msg of the entry is written with "fields." in front of its
+// key instead, like "fields.time", so that the object never has the same key
+// twice.
+type JSONFormatter struct {
+	// TimeLayout is the layout used to write the time of each entry. It can be
+	// one of the TimeLayout values or any layout accepted by time.Format. If it
+	// is blank then TimeLayout_RFC3339Nano is used. When TimeLayout_UnixMillis
+	// is used the time is written as a number instead of a string.
+	TimeLayout string
+
+	// UTC will write the time of each entry in UTC instead of local time.
+	UTC bool
+}
+
+// Format will write the entry to the buffer as a JSON object.
+func (f *JSONFormatter) Format(buf *bytes.Buffer, entry *Entry) error {
+	buf.WriteString(`{"level":`)
+	writeJSONString(buf, levelNames[entry.Level])
+	buf.WriteString(`,"time":`)
+	f.writeTime(buf, entry.Time)
+	if len(entry.Caller) > 0 {
+		buf.WriteString(`,"caller":`)
+		writeJSONString(buf, entry.Caller)
+	}
+	if len(entry.Function) > 0 {
+		buf.WriteString(`,"function":`)
+		writeJSONString(buf, entry.Function)
+	}
+	if len(entry.Name) > 0 {
+		buf.WriteString(`,"logger":`)
+		writeJSONString(buf, entry.Name)
+	}
+	if len(entry.Prefix) > 0 {
+		buf.WriteString(`,"prefix":`)
+		writeJSONString(buf, entry.Prefix)
+	}
+	buf.WriteString(`,"msg":`)
+	writeJSONString(buf, entry.Message)
+	for _, field := range entry.Fields {
+		key := jsonFieldKey(field.Key, entry)
+		buf.WriteByte(',')
+		writeJSONString(buf, key)
+		buf.WriteByte(':')
+		writeJSONField(buf, field)
+		if err, ok := field.Value.(error); ok && field.Type == FieldType_Error {
+			writeJSONErrorDetails(buf, key, err)
+		}
+	}
+	writeJSONStack(buf, entry.Stack)
+	buf.WriteString("}\n")
+	return nil
+}
+
+func (f *JSONFormatter) writeTime(buf *bytes.Buffer, t time.Time) {
+	var tmp [64]byte
+	switch f.TimeLayout {
+	case "":
+		buf.WriteByte('"')
+		buf.Write(appendTime(tmp[:0], t, TimeLayout_RFC3339Nano, f.UTC))
+		buf.WriteByte('"')
+	case TimeLayout_UnixMillis:
+		buf.Write(strconv.AppendInt(tmp[:0], t.UnixNano()/int64(time.Millisecond), 10))
+	default:
+		writeJSONString(buf, string(appendTime(tmp[:0], t, f.TimeLayout, f.UTC)))
+	}
+}
+
+// jsonFieldKey will return the key that a field is written with. Fields that
+// have the same key as a built in field or the details of an error field that
+// are written for the entry are prefixed with "fields.".
+func jsonFieldKey(key string, entry *Entry) string {
+	if isBuiltInJSONKey(key, entry) || isErrorDetailKey(key, entry) {
+		return "fields." + key
+	}
+	return key
+}
+
+// isBuiltInJSONKey will return true if the key is one of the built in fields
+// that is written for the entry.
+func isBuiltInJSONKey(key string, entry *Entry) bool {
+	switch key {
+	case "level", "time", "msg":
+		return true
+	case "caller":
+		return len(entry.Caller) > 0
+	case "function":
+		return len(entry.Function) > 0
+	case "logger":
+		return len(entry.Name) > 0
+	case "prefix":
+		return len(entry.Prefix) > 0
+	case "stack":
+		return len(entry.Stack) > 0
+	case "goroutines":
+		return len(entry.Stack) > 1
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package timber
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestJSONFormatter_Format(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := (&JSONFormatter{UTC: true}).Format(buf, &Entry{
+			Level:  Level_Warning,
+			Time:   time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+			Caller: "file.go:12",
+			Prefix: "prefix",
+			Fields: []Field{
+				String("things", "stuff"),
+				Any("err", errors.New("bad")),
+				String("msg", "not the message"),
+			},
+			Message: "test",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, byte('\n'), buf.Bytes()[buf.Len()-1])
+
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, map[string]interface{}{
+			"level":    "Warning",
+			"time":     "2019-05-01T12:00:00Z",
+			"caller":   "file.go:12",
+			"prefix":   "prefix",
+			"msg":      "test",
+			"things":   "stuff",
+			"err":      "bad",
+			"err_type": "*errors.errorString",
+
+			"fields.msg": "not the message",
+		}, obj)
+	})
+
+	t.Run("logger", func(t *testing.T) {
+		SetLevel(Level_Trace)
+		buf := bytes.NewBuffer(nil)
+		New().SetOutput(buf).SetFormatter(&JSONFormatter{}).With(Keys{"a": 1}).Info("test")
+
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.NotContains(t, obj, "prefix")
+		assert.Equal(t, float64(1), obj["a"])
+	})
+
+	t.Run("function", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		err := (&JSONFormatter{}).Format(buf, &Entry{
+			Level:    Level_Info,
+			Function: "main.main",
+			Fields:   []Field{String("function", "shadowed")},
+			Message:  "test",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"function"`)), buf.String())
+		assert.Contains(t, buf.String(), `"function":"main.main","msg":"test","fields.function":"shadowed"`)
+
+		buf.Reset()
+		err = (&JSONFormatter{}).Format(buf, &Entry{
+			Level:   Level_Info,
+			Fields:  []Field{String("function", "kept"), String("caller", "kept")},
+			Message: "test",
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, buf.String(), `"msg":"test","function":"kept","caller":"kept"}`)
+	})
+
+	t.Run("unsupported value", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		ch := make(chan int)
+		err := (&JSONFormatter{}).Format(buf, &Entry{
+			Level:   Level_Error,
+			Message: "test",
+			Fields:  []Field{Any("ch", ch)},
+		})
+		assert.NoError(t, err)
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, "test", obj["msg"])
+		assert.Equal(t, fmt.Sprint(ch), obj["ch"])
+	})
+}
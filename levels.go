@@ -5,8 +5,12 @@
 package timber
 
 import (
+	"context"
 	"fmt"
 	"github.com/logrusorgru/aurora"
+	"io"
+	"strconv"
+	"strings"
 )
 
 type colorFunc func(arg interface{}) aurora.Value
@@ -62,8 +66,80 @@ var (
 		Level_Critical: "CRIT",
 		Level_Fatal:    "FATL",
 	}
+
+	terminalActions = map[Level]terminalAction{
+		Level_Critical: terminalAction_Panic,
+		Level_Fatal:    terminalAction_Exit,
+	}
+
+	syslogSeverities = map[Level]SyslogSeverity{
+		Level_Trace:    7,
+		Level_Verbose:  7,
+		Level_Debug:    7,
+		Level_Info:     6,
+		Level_Warning:  4,
+		Level_Error:    3,
+		Level_Critical: 2,
+		Level_Fatal:    1,
+	}
 )
 
+// ParseLevel will convert the name, short name or number of a level into a
+// Level, case is ignored. The number 0 is also accepted and means that there is
+// no minimum level.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace", "trce", "1":
+		return Level_Trace, nil
+	case "verbose", "verb", "2":
+		return Level_Verbose, nil
+	case "debug", "dbug", "3":
+		return Level_Debug, nil
+	case "info", "4":
+		return Level_Info, nil
+	case "warning", "warn", "5":
+		return Level_Warning, nil
+	case "error", "errr", "6":
+		return Level_Error, nil
+	case "critical", "crit", "7":
+		return Level_Critical, nil
+	case "fatal", "fatl", "8":
+		return Level_Fatal, nil
+	case "0":
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("timber: unknown level %q", s)
+	}
+}
+
+// String will return the name of the level.
+func (l Level) String() string {
+	if name, ok := levelNames[l]; ok {
+		return name
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// MarshalText implements encoding.TextMarshaler. Levels are written using their
+// name, or their number if they do not have a name.
+func (l Level) MarshalText() ([]byte, error) {
+	if name, ok := levelNames[l]; ok {
+		return []byte(name), nil
+	}
+	return []byte(strconv.Itoa(int(l))), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Any text that is accepted
+// by ParseLevel can be unmarshalled.
+func (l *Level) UnmarshalText(text []byte) error {
+	lvl, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*l = lvl
+	return nil
+}
+
 type Logger interface {
 	// Trace writes the provided string to the log.
 	Trace(msg interface{})
@@ -76,6 +152,18 @@ type Logger interface {
 	// runtime variables.
 	TraceEx(keys Keys, msg string, args ...interface{})
 
+	// Tracew writes the provided string to the log along with the typed fields
+	// provided.
+	Tracew(msg string, fields ...Field)
+
+	// TraceCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	TraceCtx(ctx context.Context, msg string, args ...interface{})
+
+	// TraceE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	TraceE(err error, msg string, args ...interface{})
+
 	// Verbose writes the provided string to the log.
 	Verbose(msg interface{})
 
@@ -87,6 +175,18 @@ type Logger interface {
 	// runtime variables.
 	VerboseEx(keys Keys, msg string, args ...interface{})
 
+	// Verbosew writes the provided string to the log along with the typed fields
+	// provided.
+	Verbosew(msg string, fields ...Field)
+
+	// VerboseCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	VerboseCtx(ctx context.Context, msg string, args ...interface{})
+
+	// VerboseE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	VerboseE(err error, msg string, args ...interface{})
+
 	// Debug writes the provided string to the log.
 	Debug(msg interface{})
 
@@ -98,6 +198,18 @@ type Logger interface {
 	// runtime variables.
 	DebugEx(keys Keys, msg string, args ...interface{})
 
+	// Debugw writes the provided string to the log along with the typed fields
+	// provided.
+	Debugw(msg string, fields ...Field)
+
+	// DebugCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	DebugCtx(ctx context.Context, msg string, args ...interface{})
+
+	// DebugE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	DebugE(err error, msg string, args ...interface{})
+
 	// Info writes the provided string to the log.
 	Info(msg interface{})
 
@@ -109,6 +221,18 @@ type Logger interface {
 	// runtime variables.
 	InfoEx(keys Keys, msg string, args ...interface{})
 
+	// Infow writes the provided string to the log along with the typed fields
+	// provided.
+	Infow(msg string, fields ...Field)
+
+	// InfoCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	InfoCtx(ctx context.Context, msg string, args ...interface{})
+
+	// InfoE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	InfoE(err error, msg string, args ...interface{})
+
 	// Warning writes the provided string to the log.
 	Warning(msg interface{})
 
@@ -120,6 +244,18 @@ type Logger interface {
 	// runtime variables.
 	WarningEx(keys Keys, msg string, args ...interface{})
 
+	// Warningw writes the provided string to the log along with the typed fields
+	// provided.
+	Warningw(msg string, fields ...Field)
+
+	// WarningCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	WarningCtx(ctx context.Context, msg string, args ...interface{})
+
+	// WarningE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	WarningE(err error, msg string, args ...interface{})
+
 	// Error writes the provided string to the log.
 	Error(msg interface{})
 
@@ -131,28 +267,88 @@ type Logger interface {
 	// runtime variables.
 	ErrorEx(keys Keys, msg string, args ...interface{})
 
+	// Errorw writes the provided string to the log along with the typed fields
+	// provided.
+	Errorw(msg string, fields ...Field)
+
+	// ErrorCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	ErrorCtx(ctx context.Context, msg string, args ...interface{})
+
+	// ErrorE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	ErrorE(err error, msg string, args ...interface{})
+
 	// Critical writes the provided string to the log.
+	// If panics have been enabled via SetPanicEnabled then this will panic once the
+	// entry has been written.
 	Critical(msg interface{})
 
 	// Criticalf writes a formatted string using the arguments provided to the log.
+	// If panics have been enabled via SetPanicEnabled then this will panic once the
+	// entry has been written.
 	Criticalf(msg string, args ...interface{})
 
 	// CriticalEx writes a formatted string using the arguments provided to the log
 	// but also will prefix the log message with they keys provided to help print
 	// runtime variables.
+	// If panics have been enabled via SetPanicEnabled then this will panic once the
+	// entry has been written.
 	CriticalEx(keys Keys, msg string, args ...interface{})
 
+	// Criticalw writes the provided string to the log along with the typed fields
+	// provided.
+	// If panics have been enabled via SetPanicEnabled then this will panic once the
+	// entry has been written.
+	Criticalw(msg string, fields ...Field)
+
+	// CriticalCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	// If panics have been enabled via SetPanicEnabled then this will panic once the
+	// entry has been written.
+	CriticalCtx(ctx context.Context, msg string, args ...interface{})
+
+	// CriticalE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	// If panics have been enabled via SetPanicEnabled then this will panic once the
+	// entry has been written.
+	CriticalE(err error, msg string, args ...interface{})
+
 	// Fatal writes the provided string to the log.
+	// Once the entry has been written all sinks are synced and the process will
+	// exit with a status of 1, see SetExitFunc.
 	Fatal(msg interface{})
 
 	// Fatalf writes a formatted string using the arguments provided to the log.
+	// Once the entry has been written all sinks are synced and the process will
+	// exit with a status of 1, see SetExitFunc.
 	Fatalf(msg string, args ...interface{})
 
 	// FatalEx writes a formatted string using the arguments provided to the log
 	// but also will prefix the log message with they keys provided to help print
 	// runtime variables.
+	// Once the entry has been written all sinks are synced and the process will
+	// exit with a status of 1, see SetExitFunc.
 	FatalEx(keys Keys, msg string, args ...interface{})
 
+	// Fatalw writes the provided string to the log along with the typed fields
+	// provided.
+	// Once the entry has been written all sinks are synced and the process will
+	// exit with a status of 1, see SetExitFunc.
+	Fatalw(msg string, fields ...Field)
+
+	// FatalCtx writes a formatted string using the arguments provided to the log
+	// along with the fields of every registered ContextExtractor.
+	// Once the entry has been written all sinks are synced and the process will
+	// exit with a status of 1, see SetExitFunc.
+	FatalCtx(ctx context.Context, msg string, args ...interface{})
+
+	// FatalE writes a formatted string using the arguments provided to the log
+	// along with the error provided, see Err.
+	// Once the entry has been written all sinks are synced and the process will
+	// exit with a status of 1, see SetExitFunc.
+	FatalE(err error, msg string, args ...interface{})
+
 	// SetDepth will change the number of stacks that will be skipped to find
 	// the filepath and line number of the executed code.
 	SetDepth(depth int) Logger
@@ -168,278 +364,970 @@ type Logger interface {
 	// are written with every message.
 	With(keys Keys) Logger
 
+	// WithFields will create a new Logger interface the same way that With does, but
+	// with typed fields instead of keys.
+	WithFields(fields ...Field) Logger
+
 	// Prefix will add a small string before the file path.
 	Prefix(prefix string) Logger
+
+	// SetOutput will change the sink that this logger and any loggers created from
+	// it via With will write to. If the output is nil then the logger will write to
+	// the global output instead.
+	SetOutput(w io.Writer) Logger
+
+	// SetFormatter will change how entries are rendered by this logger and any
+	// loggers created from it via With. If the formatter is nil then the logger
+	// will use the global formatter instead.
+	SetFormatter(f Formatter) Logger
+
+	// SetLevel will set the minimum message level that will be written by this
+	// logger and any loggers created from it via With, overriding the global level.
+	// Setting the level to 0 will make the logger inherit its level again.
+	SetLevel(lvl Level) Logger
+
+	// Level will return the minimum message level that will be written by this
+	// logger. If the level has not been set on this logger or any of the loggers it
+	// was created from then this is the global level.
+	Level() Level
+
+	// Name will return the dotted name of the logger, loggers created via With will
+	// have the same name as the logger they were created from. The global logger
+	// and loggers created via New do not have a name.
+	Name() string
+
+	// SetColor will force ANSI colors to be enabled or disabled for this logger and
+	// any loggers created from it via With. If it is not set then colors are only
+	// used when NO_COLOR is not set and either FORCE_COLOR is set or the output of
+	// the logger is a terminal.
+	SetColor(enabled bool) Logger
+
+	// SetStack will capture stacks for entries at or above the level provided that
+	// are written by this logger and any loggers created from it via With. The mode
+	// decides whether only the goroutine that wrote the entry or every goroutine is
+	// captured. Setting the mode to 0 will make the logger inherit the stack
+	// settings again.
+	SetStack(lvl Level, mode StackMode) Logger
+
+	// SetCaller will change how the caller of each entry is written by this logger
+	// and any loggers created from it via With. If the function is true then the
+	// name of the function that wrote the entry is included. Setting the mode to 0
+	// will make the logger inherit the caller settings again.
+	SetCaller(mode CallerMode, function bool) Logger
 }
 
 // Trace writes the provided string to the log.
 func (l *logger) Trace(msg interface{}) {
-	l.log(l.stackDepth, Level_Trace, nil, msg)
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.log(l.stackDepth, Level_Trace, nil, nil, msg)
 }
 
 // Tracef writes a formatted string using the arguments provided to the log.
 func (l *logger) Tracef(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Trace, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.log(l.stackDepth, Level_Trace, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // TraceEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func (l *logger) TraceEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Trace, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.log(l.stackDepth, Level_Trace, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Tracew writes the provided string to the log along with the typed fields
+// provided.
+func (l *logger) Tracew(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.log(l.stackDepth, Level_Trace, nil, fields, msg)
+}
+
+// TraceCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+func (l *logger) TraceCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Trace, fmt.Sprintf(msg, args...))
+}
+
+// TraceE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func (l *logger) TraceE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.log(l.stackDepth, Level_Trace, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Verbose writes the provided string to the log.
 func (l *logger) Verbose(msg interface{}) {
-	l.log(l.stackDepth, Level_Verbose, nil, msg)
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.log(l.stackDepth, Level_Verbose, nil, nil, msg)
 }
 
 // Verbosef writes a formatted string using the arguments provided to the log.
 func (l *logger) Verbosef(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Verbose, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.log(l.stackDepth, Level_Verbose, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // VerboseEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func (l *logger) VerboseEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Verbose, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.log(l.stackDepth, Level_Verbose, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Verbosew writes the provided string to the log along with the typed fields
+// provided.
+func (l *logger) Verbosew(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.log(l.stackDepth, Level_Verbose, nil, fields, msg)
+}
+
+// VerboseCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+func (l *logger) VerboseCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Verbose, fmt.Sprintf(msg, args...))
+}
+
+// VerboseE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func (l *logger) VerboseE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.log(l.stackDepth, Level_Verbose, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Debug writes the provided string to the log.
 func (l *logger) Debug(msg interface{}) {
-	l.log(l.stackDepth, Level_Debug, nil, msg)
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.log(l.stackDepth, Level_Debug, nil, nil, msg)
 }
 
 // Debugf writes a formatted string using the arguments provided to the log.
 func (l *logger) Debugf(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Debug, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.log(l.stackDepth, Level_Debug, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // DebugEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func (l *logger) DebugEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Debug, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.log(l.stackDepth, Level_Debug, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Debugw writes the provided string to the log along with the typed fields
+// provided.
+func (l *logger) Debugw(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.log(l.stackDepth, Level_Debug, nil, fields, msg)
+}
+
+// DebugCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+func (l *logger) DebugCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Debug, fmt.Sprintf(msg, args...))
+}
+
+// DebugE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func (l *logger) DebugE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.log(l.stackDepth, Level_Debug, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Info writes the provided string to the log.
 func (l *logger) Info(msg interface{}) {
-	l.log(l.stackDepth, Level_Info, nil, msg)
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.log(l.stackDepth, Level_Info, nil, nil, msg)
 }
 
 // Infof writes a formatted string using the arguments provided to the log.
 func (l *logger) Infof(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Info, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.log(l.stackDepth, Level_Info, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // InfoEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func (l *logger) InfoEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Info, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.log(l.stackDepth, Level_Info, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Infow writes the provided string to the log along with the typed fields
+// provided.
+func (l *logger) Infow(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.log(l.stackDepth, Level_Info, nil, fields, msg)
+}
+
+// InfoCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+func (l *logger) InfoCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Info, fmt.Sprintf(msg, args...))
+}
+
+// InfoE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func (l *logger) InfoE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.log(l.stackDepth, Level_Info, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Warning writes the provided string to the log.
 func (l *logger) Warning(msg interface{}) {
-	l.log(l.stackDepth, Level_Warning, nil, msg)
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.log(l.stackDepth, Level_Warning, nil, nil, msg)
 }
 
 // Warningf writes a formatted string using the arguments provided to the log.
 func (l *logger) Warningf(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Warning, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.log(l.stackDepth, Level_Warning, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // WarningEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func (l *logger) WarningEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Warning, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.log(l.stackDepth, Level_Warning, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Warningw writes the provided string to the log along with the typed fields
+// provided.
+func (l *logger) Warningw(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.log(l.stackDepth, Level_Warning, nil, fields, msg)
+}
+
+// WarningCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+func (l *logger) WarningCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Warning, fmt.Sprintf(msg, args...))
+}
+
+// WarningE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func (l *logger) WarningE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.log(l.stackDepth, Level_Warning, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Error writes the provided string to the log.
 func (l *logger) Error(msg interface{}) {
-	l.log(l.stackDepth, Level_Error, nil, msg)
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.log(l.stackDepth, Level_Error, nil, nil, msg)
 }
 
 // Errorf writes a formatted string using the arguments provided to the log.
 func (l *logger) Errorf(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Error, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.log(l.stackDepth, Level_Error, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // ErrorEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func (l *logger) ErrorEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Error, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.log(l.stackDepth, Level_Error, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Errorw writes the provided string to the log along with the typed fields
+// provided.
+func (l *logger) Errorw(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.log(l.stackDepth, Level_Error, nil, fields, msg)
+}
+
+// ErrorCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+func (l *logger) ErrorCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Error, fmt.Sprintf(msg, args...))
+}
+
+// ErrorE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func (l *logger) ErrorE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.log(l.stackDepth, Level_Error, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Critical writes the provided string to the log.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
 func (l *logger) Critical(msg interface{}) {
-	l.log(l.stackDepth, Level_Critical, nil, msg)
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.log(l.stackDepth, Level_Critical, nil, nil, msg)
 }
 
 // Criticalf writes a formatted string using the arguments provided to the log.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
 func (l *logger) Criticalf(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Critical, nil, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.log(l.stackDepth, Level_Critical, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // CriticalEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
 func (l *logger) CriticalEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Critical, keys, fmt.Sprintf(msg, args...))
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.log(l.stackDepth, Level_Critical, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Criticalw writes the provided string to the log along with the typed fields
+// provided.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
+func (l *logger) Criticalw(msg string, fields ...Field) {
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.log(l.stackDepth, Level_Critical, nil, fields, msg)
+}
+
+// CriticalCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
+func (l *logger) CriticalCtx(ctx context.Context, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Critical, fmt.Sprintf(msg, args...))
+}
+
+// CriticalE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
+func (l *logger) CriticalE(err error, msg string, args ...interface{}) {
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.log(l.stackDepth, Level_Critical, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Fatal writes the provided string to the log.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
 func (l *logger) Fatal(msg interface{}) {
-	l.log(l.stackDepth, Level_Fatal, nil, msg)
+	l.log(l.stackDepth, Level_Fatal, nil, nil, msg)
 }
 
 // Fatalf writes a formatted string using the arguments provided to the log.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
 func (l *logger) Fatalf(msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Fatal, nil, fmt.Sprintf(msg, args...))
+	l.log(l.stackDepth, Level_Fatal, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // FatalEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
 func (l *logger) FatalEx(keys Keys, msg string, args ...interface{}) {
-	l.log(l.stackDepth, Level_Fatal, keys, fmt.Sprintf(msg, args...))
+	l.log(l.stackDepth, Level_Fatal, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Fatalw writes the provided string to the log along with the typed fields
+// provided.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
+func (l *logger) Fatalw(msg string, fields ...Field) {
+	l.log(l.stackDepth, Level_Fatal, nil, fields, msg)
+}
+
+// FatalCtx writes a formatted string using the arguments provided to the log
+// along with the fields of every registered ContextExtractor.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
+func (l *logger) FatalCtx(ctx context.Context, msg string, args ...interface{}) {
+	l.logCtx(ctx, l.stackDepth, Level_Fatal, fmt.Sprintf(msg, args...))
+}
+
+// FatalE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
+func (l *logger) FatalE(err error, msg string, args ...interface{}) {
+	l.log(l.stackDepth, Level_Fatal, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Trace writes the provided string to the log.
 func Trace(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, nil, msg)
+	if !defaultLogger.shouldLog(Level_Trace) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, nil, nil, msg)
 }
 
 // Tracef writes a formatted string using the arguments provided to the log.
 func Tracef(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Trace) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // TraceEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func TraceEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Trace) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Tracew writes the provided string to the log along with the typed fields
+// provided.
+func Tracew(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Trace) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, nil, fields, msg)
+}
+
+// TraceCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+func TraceCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.TraceCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Trace) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Trace, fmt.Sprintf(msg, args...))
+}
+
+// TraceE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func TraceE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Trace) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Trace, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Verbose writes the provided string to the log.
 func Verbose(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, nil, msg)
+	if !defaultLogger.shouldLog(Level_Verbose) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, nil, nil, msg)
 }
 
 // Verbosef writes a formatted string using the arguments provided to the log.
 func Verbosef(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Verbose) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // VerboseEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func VerboseEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Verbose) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Verbosew writes the provided string to the log along with the typed fields
+// provided.
+func Verbosew(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Verbose) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, nil, fields, msg)
+}
+
+// VerboseCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+func VerboseCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.VerboseCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Verbose) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Verbose, fmt.Sprintf(msg, args...))
+}
+
+// VerboseE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func VerboseE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Verbose) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Verbose, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Debug writes the provided string to the log.
 func Debug(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, nil, msg)
+	if !defaultLogger.shouldLog(Level_Debug) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, nil, nil, msg)
 }
 
 // Debugf writes a formatted string using the arguments provided to the log.
 func Debugf(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Debug) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // DebugEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func DebugEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Debug) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Debugw writes the provided string to the log along with the typed fields
+// provided.
+func Debugw(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Debug) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, nil, fields, msg)
+}
+
+// DebugCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+func DebugCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.DebugCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Debug) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Debug, fmt.Sprintf(msg, args...))
+}
+
+// DebugE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func DebugE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Debug) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Debug, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Info writes the provided string to the log.
 func Info(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Info, nil, msg)
+	if !defaultLogger.shouldLog(Level_Info) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Info, nil, nil, msg)
 }
 
 // Infof writes a formatted string using the arguments provided to the log.
 func Infof(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Info, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Info) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Info, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // InfoEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func InfoEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Info, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Info) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Info, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Infow writes the provided string to the log along with the typed fields
+// provided.
+func Infow(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Info) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Info, nil, fields, msg)
+}
+
+// InfoCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+func InfoCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.InfoCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Info) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Info, fmt.Sprintf(msg, args...))
+}
+
+// InfoE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func InfoE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Info) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Info, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Warning writes the provided string to the log.
 func Warning(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, nil, msg)
+	if !defaultLogger.shouldLog(Level_Warning) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, nil, nil, msg)
 }
 
 // Warningf writes a formatted string using the arguments provided to the log.
 func Warningf(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Warning) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // WarningEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func WarningEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Warning) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Warningw writes the provided string to the log along with the typed fields
+// provided.
+func Warningw(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Warning) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, nil, fields, msg)
+}
+
+// WarningCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+func WarningCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.WarningCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Warning) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Warning, fmt.Sprintf(msg, args...))
+}
+
+// WarningE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func WarningE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Warning) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Warning, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Error writes the provided string to the log.
 func Error(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Error, nil, msg)
+	if !defaultLogger.shouldLog(Level_Error) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Error, nil, nil, msg)
 }
 
 // Errorf writes a formatted string using the arguments provided to the log.
 func Errorf(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Error, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Error) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Error, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // ErrorEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
 func ErrorEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Error, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Error) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Error, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Errorw writes the provided string to the log along with the typed fields
+// provided.
+func Errorw(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Error) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Error, nil, fields, msg)
+}
+
+// ErrorCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+func ErrorCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.ErrorCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Error) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Error, fmt.Sprintf(msg, args...))
+}
+
+// ErrorE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+func ErrorE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Error) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Error, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Critical writes the provided string to the log.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
 func Critical(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, nil, msg)
+	if !defaultLogger.shouldLog(Level_Critical) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, nil, nil, msg)
 }
 
 // Criticalf writes a formatted string using the arguments provided to the log.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
 func Criticalf(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, nil, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Critical) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // CriticalEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
 func CriticalEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, keys, fmt.Sprintf(msg, args...))
+	if !defaultLogger.shouldLog(Level_Critical) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Criticalw writes the provided string to the log along with the typed fields
+// provided.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
+func Criticalw(msg string, fields ...Field) {
+	if !defaultLogger.shouldLog(Level_Critical) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, nil, fields, msg)
+}
+
+// CriticalCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
+func CriticalCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.CriticalCtx(ctx, msg, args...)
+		return
+	}
+	if !l.shouldLog(Level_Critical) {
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Critical, fmt.Sprintf(msg, args...))
+}
+
+// CriticalE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+// If panics have been enabled via SetPanicEnabled then this will panic once the
+// entry has been written.
+func CriticalE(err error, msg string, args ...interface{}) {
+	if !defaultLogger.shouldLog(Level_Critical) {
+		return
+	}
+	defaultLogger.log(defaultLogger.stackDepth, Level_Critical, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
 
 // Fatal writes the provided string to the log.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
 func Fatal(msg interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, nil, msg)
+	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, nil, nil, msg)
 }
 
 // Fatalf writes a formatted string using the arguments provided to the log.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
 func Fatalf(msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, nil, fmt.Sprintf(msg, args...))
+	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, nil, nil, fmt.Sprintf(msg, args...))
 }
 
 // FatalEx writes a formatted string using the arguments provided to the log
 // but also will prefix the log message with they keys provided to help print
 // runtime variables.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
 func FatalEx(keys Keys, msg string, args ...interface{}) {
-	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, keys, fmt.Sprintf(msg, args...))
+	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, keys, nil, fmt.Sprintf(msg, args...))
+}
+
+// Fatalw writes the provided string to the log along with the typed fields
+// provided.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
+func Fatalw(msg string, fields ...Field) {
+	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, nil, fields, msg)
+}
+
+// FatalCtx writes a formatted string using the arguments provided to the log
+// of the context, or the global logger if the context does not have one. The
+// fields of every registered ContextExtractor are included.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
+func FatalCtx(ctx context.Context, msg string, args ...interface{}) {
+	lg := FromContext(ctx)
+	l, ok := lg.(*logger)
+	if !ok {
+		lg.FatalCtx(ctx, msg, args...)
+		return
+	}
+	l.logCtx(ctx, l.stackDepth, Level_Fatal, fmt.Sprintf(msg, args...))
+}
+
+// FatalE writes a formatted string using the arguments provided to the log
+// along with the error provided, see Err.
+// Once the entry has been written all sinks are synced and the process will
+// exit with a status of 1, see SetExitFunc.
+func FatalE(err error, msg string, args ...interface{}) {
+	defaultLogger.log(defaultLogger.stackDepth, Level_Fatal, nil, []Field{Err(err)}, fmt.Sprintf(msg, args...))
 }
@@ -5,9 +5,29 @@
 package timber
 
 import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
+func TestParseLevel_Trace(t *testing.T) {
+	for _, s := range []string{"Trace", "trace", "TRCE", "trce", "1"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Trace, lvl)
+	}
+}
+
+func TestLevel_Trace_Text(t *testing.T) {
+	assert.Equal(t, "Trace", Level_Trace.String())
+	text, err := Level_Trace.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Trace, lvl)
+}
+
 func TestTrace(t *testing.T) {
 	Trace("test")
 }
@@ -36,6 +56,47 @@ func TestLogger_TraceEx(t *testing.T) {
 	}, "test")
 }
 
+func TestTracew(t *testing.T) {
+	Tracew("test", String("thing", "stuff"))
+}
+
+func TestLogger_Tracew(t *testing.T) {
+	New().Tracew("test", String("thing", "stuff"))
+}
+
+func TestTraceCtx(t *testing.T) {
+	TraceCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_TraceCtx(t *testing.T) {
+	New().TraceCtx(context.Background(), "test %s", "format")
+}
+
+func TestTraceE(t *testing.T) {
+	TraceE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_TraceE(t *testing.T) {
+	New().TraceE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Verbose(t *testing.T) {
+	for _, s := range []string{"Verbose", "verbose", "VERB", "verb", "2"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Verbose, lvl)
+	}
+}
+
+func TestLevel_Verbose_Text(t *testing.T) {
+	assert.Equal(t, "Verbose", Level_Verbose.String())
+	text, err := Level_Verbose.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Verbose, lvl)
+}
+
 func TestVerbose(t *testing.T) {
 	Verbose("test")
 }
@@ -64,6 +125,47 @@ func TestLogger_VerboseEx(t *testing.T) {
 	}, "test")
 }
 
+func TestVerbosew(t *testing.T) {
+	Verbosew("test", String("thing", "stuff"))
+}
+
+func TestLogger_Verbosew(t *testing.T) {
+	New().Verbosew("test", String("thing", "stuff"))
+}
+
+func TestVerboseCtx(t *testing.T) {
+	VerboseCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_VerboseCtx(t *testing.T) {
+	New().VerboseCtx(context.Background(), "test %s", "format")
+}
+
+func TestVerboseE(t *testing.T) {
+	VerboseE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_VerboseE(t *testing.T) {
+	New().VerboseE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Debug(t *testing.T) {
+	for _, s := range []string{"Debug", "debug", "DBUG", "dbug", "3"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Debug, lvl)
+	}
+}
+
+func TestLevel_Debug_Text(t *testing.T) {
+	assert.Equal(t, "Debug", Level_Debug.String())
+	text, err := Level_Debug.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Debug, lvl)
+}
+
 func TestDebug(t *testing.T) {
 	Debug("test")
 }
@@ -92,6 +194,47 @@ func TestLogger_DebugEx(t *testing.T) {
 	}, "test")
 }
 
+func TestDebugw(t *testing.T) {
+	Debugw("test", String("thing", "stuff"))
+}
+
+func TestLogger_Debugw(t *testing.T) {
+	New().Debugw("test", String("thing", "stuff"))
+}
+
+func TestDebugCtx(t *testing.T) {
+	DebugCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_DebugCtx(t *testing.T) {
+	New().DebugCtx(context.Background(), "test %s", "format")
+}
+
+func TestDebugE(t *testing.T) {
+	DebugE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_DebugE(t *testing.T) {
+	New().DebugE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Info(t *testing.T) {
+	for _, s := range []string{"Info", "info", "INFO", "info", "4"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Info, lvl)
+	}
+}
+
+func TestLevel_Info_Text(t *testing.T) {
+	assert.Equal(t, "Info", Level_Info.String())
+	text, err := Level_Info.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Info, lvl)
+}
+
 func TestInfo(t *testing.T) {
 	Info("test")
 }
@@ -120,6 +263,47 @@ func TestLogger_InfoEx(t *testing.T) {
 	}, "test")
 }
 
+func TestInfow(t *testing.T) {
+	Infow("test", String("thing", "stuff"))
+}
+
+func TestLogger_Infow(t *testing.T) {
+	New().Infow("test", String("thing", "stuff"))
+}
+
+func TestInfoCtx(t *testing.T) {
+	InfoCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_InfoCtx(t *testing.T) {
+	New().InfoCtx(context.Background(), "test %s", "format")
+}
+
+func TestInfoE(t *testing.T) {
+	InfoE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_InfoE(t *testing.T) {
+	New().InfoE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Warning(t *testing.T) {
+	for _, s := range []string{"Warning", "warning", "WARN", "warn", "5"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Warning, lvl)
+	}
+}
+
+func TestLevel_Warning_Text(t *testing.T) {
+	assert.Equal(t, "Warning", Level_Warning.String())
+	text, err := Level_Warning.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Warning, lvl)
+}
+
 func TestWarning(t *testing.T) {
 	Warning("test")
 }
@@ -148,6 +332,47 @@ func TestLogger_WarningEx(t *testing.T) {
 	}, "test")
 }
 
+func TestWarningw(t *testing.T) {
+	Warningw("test", String("thing", "stuff"))
+}
+
+func TestLogger_Warningw(t *testing.T) {
+	New().Warningw("test", String("thing", "stuff"))
+}
+
+func TestWarningCtx(t *testing.T) {
+	WarningCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_WarningCtx(t *testing.T) {
+	New().WarningCtx(context.Background(), "test %s", "format")
+}
+
+func TestWarningE(t *testing.T) {
+	WarningE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_WarningE(t *testing.T) {
+	New().WarningE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Error(t *testing.T) {
+	for _, s := range []string{"Error", "error", "ERRR", "errr", "6"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Error, lvl)
+	}
+}
+
+func TestLevel_Error_Text(t *testing.T) {
+	assert.Equal(t, "Error", Level_Error.String())
+	text, err := Level_Error.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Error, lvl)
+}
+
 func TestError(t *testing.T) {
 	Error("test")
 }
@@ -176,6 +401,47 @@ func TestLogger_ErrorEx(t *testing.T) {
 	}, "test")
 }
 
+func TestErrorw(t *testing.T) {
+	Errorw("test", String("thing", "stuff"))
+}
+
+func TestLogger_Errorw(t *testing.T) {
+	New().Errorw("test", String("thing", "stuff"))
+}
+
+func TestErrorCtx(t *testing.T) {
+	ErrorCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_ErrorCtx(t *testing.T) {
+	New().ErrorCtx(context.Background(), "test %s", "format")
+}
+
+func TestErrorE(t *testing.T) {
+	ErrorE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_ErrorE(t *testing.T) {
+	New().ErrorE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Critical(t *testing.T) {
+	for _, s := range []string{"Critical", "critical", "CRIT", "crit", "7"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Critical, lvl)
+	}
+}
+
+func TestLevel_Critical_Text(t *testing.T) {
+	assert.Equal(t, "Critical", Level_Critical.String())
+	text, err := Level_Critical.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Critical, lvl)
+}
+
 func TestCritical(t *testing.T) {
 	Critical("test")
 }
@@ -204,30 +470,107 @@ func TestLogger_CriticalEx(t *testing.T) {
 	}, "test")
 }
 
+func TestCriticalw(t *testing.T) {
+	Criticalw("test", String("thing", "stuff"))
+}
+
+func TestLogger_Criticalw(t *testing.T) {
+	New().Criticalw("test", String("thing", "stuff"))
+}
+
+func TestCriticalCtx(t *testing.T) {
+	CriticalCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_CriticalCtx(t *testing.T) {
+	New().CriticalCtx(context.Background(), "test %s", "format")
+}
+
+func TestCriticalE(t *testing.T) {
+	CriticalE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_CriticalE(t *testing.T) {
+	New().CriticalE(errors.New("failed"), "test %s", "format")
+}
+
+func TestParseLevel_Fatal(t *testing.T) {
+	for _, s := range []string{"Fatal", "fatal", "FATL", "fatl", "8"} {
+		lvl, err := ParseLevel(s)
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Fatal, lvl)
+	}
+}
+
+func TestLevel_Fatal_Text(t *testing.T) {
+	assert.Equal(t, "Fatal", Level_Fatal.String())
+	text, err := Level_Fatal.MarshalText()
+	assert.NoError(t, err)
+	var lvl Level
+	assert.NoError(t, lvl.UnmarshalText(text))
+	assert.Equal(t, Level_Fatal, lvl)
+}
+
 func TestFatal(t *testing.T) {
+	defer expectExit(t)()
 	Fatal("test")
 }
 
 func TestFatalf(t *testing.T) {
+	defer expectExit(t)()
 	Fatalf("test %s", "format")
 }
 
 func TestFatalEx(t *testing.T) {
+	defer expectExit(t)()
 	FatalEx(map[string]interface{}{
 		"thing": "stuff",
 	}, "test")
 }
 
 func TestLogger_Fatal(t *testing.T) {
+	defer expectExit(t)()
 	New().Fatal("test")
 }
 
 func TestLogger_Fatalf(t *testing.T) {
+	defer expectExit(t)()
 	New().Fatalf("test %s", "format")
 }
 
 func TestLogger_FatalEx(t *testing.T) {
+	defer expectExit(t)()
 	New().FatalEx(map[string]interface{}{
 		"thing": "stuff",
 	}, "test")
 }
+
+func TestFatalw(t *testing.T) {
+	defer expectExit(t)()
+	Fatalw("test", String("thing", "stuff"))
+}
+
+func TestLogger_Fatalw(t *testing.T) {
+	defer expectExit(t)()
+	New().Fatalw("test", String("thing", "stuff"))
+}
+
+func TestFatalCtx(t *testing.T) {
+	defer expectExit(t)()
+	FatalCtx(NewContext(context.Background(), With(Keys{"thing": "stuff"})), "test %s", "format")
+}
+
+func TestLogger_FatalCtx(t *testing.T) {
+	defer expectExit(t)()
+	New().FatalCtx(context.Background(), "test %s", "format")
+}
+
+func TestFatalE(t *testing.T) {
+	defer expectExit(t)()
+	FatalE(errors.New("failed"), "test %s", "format")
+}
+
+func TestLogger_FatalE(t *testing.T) {
+	defer expectExit(t)()
+	New().FatalE(errors.New("failed"), "test %s", "format")
+}
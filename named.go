@@ -0,0 +1,71 @@
+package timber
+
+import (
+	"strings"
+	"sync"
+)
+
+var (
+	namedLoggers     = map[string]*logger{}
+	namedLoggersLock sync.Mutex
+)
+
+// Named will return the logger with the dotted name provided, creating it if it
+// does not already exist. Named loggers form a tree where "db" is the parent of
+// "db.pool" and "db.migrations", and the global logger is the parent of every
+// top level name. Any level, output or formatter that is set on a named logger
+// applies to all of its descendants unless they have been given their own.
+// Calling Named with the same name will always return the same logger. Empty
+// parts of the name are ignored, so "db..pool" and ".db.pool" are "db.pool".
+func Named(name string) Logger {
+	return getNamedLogger(name)
+}
+
+func getNamedLogger(name string) *logger {
+	name = normalizeName(name)
+	if len(name) == 0 {
+		return defaultLogger
+	}
+	namedLoggersLock.Lock()
+	defer namedLoggersLock.Unlock()
+	return getNamedLoggerLocked(name)
+}
+
+// normalizeName will remove any empty parts from the dotted name provided.
+func normalizeName(name string) string {
+	if !strings.HasPrefix(name, ".") && !strings.HasSuffix(name, ".") && !strings.Contains(name, "..") {
+		return name
+	}
+	parts := strings.Split(name, ".")
+	kept := parts[:0]
+	for _, part := range parts {
+		if len(part) > 0 {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, ".")
+}
+
+func getNamedLoggerLocked(name string) *logger {
+	if lg, ok := namedLoggers[name]; ok {
+		return lg
+	}
+	parent := defaultLogger
+	if i := strings.LastIndexByte(name, '.'); i > 0 {
+		parent = getNamedLoggerLocked(name[:i])
+	}
+	lg := &logger{
+		parent:     parent,
+		name:       name,
+		stackDepth: defaultStackDepth,
+	}
+	namedLoggers[name] = lg
+	return lg
+}
+
+// Name will return the dotted name of the logger, loggers created via With will
+// have the same name as the logger they were created from. The global logger
+// and loggers created via New do not have a name.
+func (l *logger) Name() string {
+	return l.name
+}
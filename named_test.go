@@ -0,0 +1,66 @@
+package timber
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+	SetLevel(Level_Debug)
+	defer SetLevel(Level_Trace)
+
+	t.Run("same logger", func(t *testing.T) {
+		assert.Equal(t, Named("named.same"), Named("named.same"))
+		assert.Equal(t, Named("named.same"), Named(".named.same."))
+		assert.Equal(t, "named.same", Named("named.same").Name())
+		assert.Equal(t, "named.same", Named("named.same").With(Keys{"a": 1}).Name())
+		assert.Equal(t, "", New().Name())
+	})
+
+	t.Run("blank is global", func(t *testing.T) {
+		assert.Equal(t, Logger(defaultLogger), Named(""))
+		assert.Equal(t, Logger(defaultLogger), Named(".."))
+	})
+
+	t.Run("empty parts", func(t *testing.T) {
+		assert.Equal(t, Named("named.empty.child"), Named("named..empty...child"))
+		assert.Equal(t, Named("named.empty"), Named("named.empty.child").(*logger).parent)
+		namedLoggersLock.Lock()
+		defer namedLoggersLock.Unlock()
+		for name := range namedLoggers {
+			assert.NotContains(t, name, "..")
+			assert.NotEqual(t, '.', name[len(name)-1], name)
+		}
+	})
+
+	t.Run("subtree configuration", func(t *testing.T) {
+		buf, migrations := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		db := Named("test_db").SetOutput(buf).SetLevel(Level_Warning)
+		pool := Named("test_db.pool")
+		assert.Equal(t, Level_Warning, pool.Level())
+		assert.Equal(t, Level_Debug, Named("test_other").Level())
+
+		Named("test_db.migrations").SetOutput(migrations)
+		Named("test_db.migrations").Warning("migrations warning")
+		pool.Info("pool info")
+		pool.Warning("pool warning")
+		assert.NotContains(t, buf.String(), "pool info")
+		assert.Contains(t, buf.String(), "pool warning")
+		assert.NotContains(t, buf.String(), "migrations warning")
+		assert.Contains(t, migrations.String(), "migrations warning")
+
+		Named("test_db.pool.conn").SetLevel(Level_Trace)
+		Named("test_db.pool.conn").Trace("conn trace")
+		assert.Contains(t, buf.String(), "conn trace")
+
+		db.SetLevel(Level_Error)
+		assert.Equal(t, Level_Error, pool.Level())
+	})
+
+	t.Run("name in json", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		Named("test_json").SetOutput(buf).SetFormatter(&JSONFormatter{}).Info("test")
+		assert.Contains(t, buf.String(), `"logger":"test_json"`)
+	})
+}
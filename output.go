@@ -0,0 +1,211 @@
+package timber
+
+import (
+	"context"
+	"io"
+	"os"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	output     io.Writer = os.Stdout
+	outputLock sync.RWMutex
+
+	// writeLocks holds a *sinkLock for each sink so that entries being written
+	// from multiple goroutines are never interleaved with one another, without
+	// a slow sink holding up the writes to every other sink.
+	writeLocks sync.Map
+
+	// writeLock serializes the writes to sinks that cannot be used as a key in
+	// writeLocks.
+	writeLock sinkLock
+
+	// sinks counts the loggers that are using each output, these are synced
+	// when Sync is called. Outputs are removed once no logger is using them or
+	// once they have been closed.
+	sinks     = map[io.Writer]int{}
+	sinksLock sync.Mutex
+)
+
+// sinkLock is the lock that writes to a sink must hold.
+type sinkLock struct {
+	sync.Mutex
+
+	// refs counts the loggers that are using the sink and the writes to it
+	// that are in progress. The lock is only removed from writeLocks once it
+	// reaches 0, at which point it is set to -1 so that it is not used again.
+	refs int32
+}
+
+func init() {
+	// The global logger writes to stdout until SetOutput is called.
+	addSink(os.Stdout)
+}
+
+type syncer interface {
+	Sync() error
+}
+
+type flusher interface {
+	Flush() error
+}
+
+type contextFlusher interface {
+	Flush(ctx context.Context) error
+}
+
+// SetOutput will change the sink that the global logger writes to. Any logger
+// that has not been given its own output via Logger.SetOutput will also start
+// writing to this sink. By default this is stdout.
+func SetOutput(w io.Writer) {
+	if w == nil {
+		w = os.Stdout
+	}
+	addSink(w)
+	outputLock.Lock()
+	previous := output
+	output = w
+	outputLock.Unlock()
+	removeSink(previous)
+}
+
+// GetOutput will return the sink that the global logger is currently writing
+// to.
+func GetOutput() io.Writer {
+	outputLock.RLock()
+	defer outputLock.RUnlock()
+	return output
+}
+
+func write(w io.Writer, lvl Level, msg []byte) {
+	lock := holdWriteLock(w)
+	defer releaseWriteLock(w, lock)
+	lock.Lock()
+	defer lock.Unlock()
+	if lw, ok := w.(LevelWriter); ok {
+		_, _ = lw.WriteLevel(lvl, msg)
+		return
+	}
+	_, _ = w.Write(msg)
+}
+
+// Sync will flush any buffered entries in every sink that is being used by a
+// logger. Sinks that implement Sync() error, Flush() error or
+// Flush(context.Context) error will be flushed, stdout and stderr are not
+// buffered and are skipped. The first error returned by a sink is returned.
+func Sync() error {
+	sinksLock.Lock()
+	current := make([]io.Writer, 0, len(sinks))
+	for sink := range sinks {
+		current = append(current, sink)
+	}
+	sinksLock.Unlock()
+	var err error
+	for _, sink := range current {
+		lock := holdWriteLock(sink)
+		lock.Lock()
+		sinkErr := syncSink(sink)
+		lock.Unlock()
+		releaseWriteLock(sink, lock)
+		if err == nil {
+			err = sinkErr
+		}
+	}
+	return err
+}
+
+// holdWriteLock will return the lock that writes to the sink provided must
+// hold. A reference to the lock is held so that it is not removed while it is
+// being used, releaseWriteLock must be called once it is no longer needed.
+func holdWriteLock(w io.Writer) *sinkLock {
+	if !reflect.TypeOf(w).Comparable() {
+		return &writeLock
+	}
+	for {
+		value, ok := writeLocks.Load(w)
+		if !ok {
+			value, _ = writeLocks.LoadOrStore(w, &sinkLock{})
+		}
+		lock := value.(*sinkLock)
+		for {
+			refs := atomic.LoadInt32(&lock.refs)
+			if refs < 0 {
+				// The lock is being removed, wait for it to be replaced.
+				runtime.Gosched()
+				break
+			}
+			if atomic.CompareAndSwapInt32(&lock.refs, refs, refs+1) {
+				return lock
+			}
+		}
+	}
+}
+
+// releaseWriteLock will release a reference to the lock of the sink provided
+// that was returned by holdWriteLock. Once nothing is using the sink its lock is
+// removed.
+func releaseWriteLock(w io.Writer, lock *sinkLock) {
+	if lock == &writeLock {
+		return
+	}
+	if atomic.AddInt32(&lock.refs, -1) == 0 && atomic.CompareAndSwapInt32(&lock.refs, 0, -1) {
+		writeLocks.Delete(w)
+	}
+}
+
+// syncSink will flush a single sink if it is buffered.
+func syncSink(sink io.Writer) error {
+	if sink == io.Writer(os.Stdout) || sink == io.Writer(os.Stderr) {
+		return nil
+	}
+	switch s := sink.(type) {
+	case syncer:
+		return s.Sync()
+	case flusher:
+		return s.Flush()
+	case contextFlusher:
+		return s.Flush(context.Background())
+	}
+	return nil
+}
+
+// addSink will record that a logger is using the output provided. Outputs that
+// cannot be compared, and so cannot be told apart, are not synced.
+func addSink(w io.Writer) {
+	if w == nil || !reflect.TypeOf(w).Comparable() {
+		return
+	}
+	holdWriteLock(w)
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+	sinks[w]++
+}
+
+// removeSink will record that a logger has stopped using the output provided,
+// once no logger is using it the output is forgotten.
+func removeSink(w io.Writer) {
+	if w == nil || !reflect.TypeOf(w).Comparable() {
+		return
+	}
+	sinksLock.Lock()
+	if sinks[w]--; sinks[w] <= 0 {
+		delete(sinks, w)
+	}
+	sinksLock.Unlock()
+	// The logger was holding a reference to the lock since addSink, so it is
+	// still stored.
+	if lock, ok := writeLocks.Load(w); ok {
+		releaseWriteLock(w, lock.(*sinkLock))
+	}
+}
+
+// forgetSink will forget the output provided even if loggers are still using
+// it, it is called when an output is closed.
+func forgetSink(w io.Writer) {
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+	delete(sinks, w)
+}
@@ -0,0 +1,188 @@
+package timber
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetOutput(t *testing.T) {
+	SetLevel(Level_Trace)
+	defer SetOutput(os.Stdout)
+
+	t.Run("global", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		SetOutput(buf)
+		assert.Equal(t, buf, GetOutput())
+		Info("global output")
+		New().Info("new output")
+		assert.Contains(t, buf.String(), "global output")
+		assert.Contains(t, buf.String(), "new output")
+	})
+
+	t.Run("nil resets to stdout", func(t *testing.T) {
+		SetOutput(nil)
+		assert.Equal(t, os.Stdout, GetOutput())
+	})
+
+	t.Run("per logger", func(t *testing.T) {
+		global, local := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		SetOutput(global)
+		log := New().SetOutput(local)
+		log.Info("local output")
+		log.With(Keys{"thing": "stuff"}).Info("inherited output")
+		Info("global output")
+		assert.Contains(t, local.String(), "local output")
+		assert.Contains(t, local.String(), "inherited output")
+		assert.NotContains(t, local.String(), "global output")
+		assert.Contains(t, global.String(), "global output")
+		assert.NotContains(t, global.String(), "local output")
+	})
+}
+
+// blockingWriter blocks every write until it is released.
+type blockingWriter struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (w *blockingWriter) Write(p []byte) (int, error) {
+	close(w.started)
+	<-w.release
+	return len(p), nil
+}
+
+func TestWrite_BlockedSink(t *testing.T) {
+	SetLevel(Level_Trace)
+	blocked := &blockingWriter{started: make(chan struct{}), release: make(chan struct{})}
+	defer close(blocked.release)
+	go New().SetOutput(blocked).Info("blocked")
+	<-blocked.started
+
+	done := make(chan struct{})
+	buf := bytes.NewBuffer(nil)
+	go func() {
+		defer close(done)
+		New().SetOutput(buf).Info("not blocked")
+	}()
+	select {
+	case <-done:
+		assert.Contains(t, buf.String(), "not blocked")
+	case <-time.After(5 * time.Second):
+		t.Fatal("a blocked sink should not stop writes to other sinks")
+	}
+}
+
+// funcWriter cannot be compared, so it cannot be tracked as a sink.
+type funcWriter func(p []byte) (int, error)
+
+func (f funcWriter) Write(p []byte) (int, error) {
+	return f(p)
+}
+
+func hasSink(w io.Writer) bool {
+	sinksLock.Lock()
+	defer sinksLock.Unlock()
+	_, ok := sinks[w]
+	return ok
+}
+
+func TestSinks(t *testing.T) {
+	defer SetOutput(os.Stdout)
+
+	t.Run("replaced", func(t *testing.T) {
+		first, second := bytes.NewBuffer(nil), bytes.NewBuffer(nil)
+		log := New().SetOutput(first)
+		shared := New().SetOutput(first)
+		assert.True(t, hasSink(first))
+		log.SetOutput(second)
+		assert.True(t, hasSink(first), "the output is still used by another logger")
+		shared.SetOutput(nil)
+		assert.False(t, hasSink(first))
+		assert.True(t, hasSink(second))
+
+		SetOutput(first)
+		SetOutput(os.Stdout)
+		assert.False(t, hasSink(first))
+	})
+
+	t.Run("not comparable", func(t *testing.T) {
+		w := funcWriter(func(p []byte) (int, error) {
+			return len(p), nil
+		})
+		New().SetOutput(w).Info("test")
+		sinksLock.Lock()
+		defer sinksLock.Unlock()
+		for sink := range sinks {
+			_, ok := sink.(funcWriter)
+			assert.False(t, ok)
+		}
+	})
+
+	t.Run("garbage collected", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		func() {
+			New().SetOutput(buf).Info("test")
+		}()
+		for i := 0; i < 50 && hasSink(buf); i++ {
+			runtime.GC()
+			time.Sleep(10 * time.Millisecond)
+		}
+		assert.False(t, hasSink(buf))
+	})
+
+	t.Run("write in progress", func(t *testing.T) {
+		w := &overlapWriter{started: make(chan struct{}), release: make(chan struct{})}
+		log := New().SetOutput(w)
+		first := make(chan struct{})
+		go func() {
+			defer close(first)
+			log.Info("first")
+		}()
+		<-w.started
+
+		// The output is replaced while it is still being written to, another
+		// goroutine that got the output before then must still wait.
+		log.SetOutput(nil)
+		second := make(chan struct{})
+		go func() {
+			defer close(second)
+			write(w, Level_Info, []byte("second"))
+		}()
+		time.Sleep(10 * time.Millisecond)
+		close(w.release)
+		<-first
+		<-second
+		assert.Equal(t, int32(2), atomic.LoadInt32(&w.writes))
+		assert.Equal(t, int32(0), atomic.LoadInt32(&w.overlaps), "writes to a sink should never overlap")
+		_, ok := writeLocks.Load(w)
+		assert.False(t, ok, "the lock should be removed once nothing is using the sink")
+	})
+}
+
+// overlapWriter counts writes that happen at the same time, the first write
+// blocks until it is released.
+type overlapWriter struct {
+	started  chan struct{}
+	release  chan struct{}
+	active   int32
+	writes   int32
+	overlaps int32
+}
+
+func (w *overlapWriter) Write(p []byte) (int, error) {
+	if atomic.AddInt32(&w.active, 1) > 1 {
+		atomic.AddInt32(&w.overlaps, 1)
+	}
+	if atomic.AddInt32(&w.writes, 1) == 1 {
+		close(w.started)
+		<-w.release
+	}
+	atomic.AddInt32(&w.active, -1)
+	return len(p), nil
+}
@@ -0,0 +1,64 @@
+package timber
+
+import (
+	"bytes"
+	"sync"
+)
+
+const (
+	// maxPooledBufferSize is the largest buffer that will be put back into the
+	// pool, larger buffers are left for the garbage collector so that a single
+	// huge entry does not keep its memory around forever.
+	maxPooledBufferSize = 64 << 10
+
+	// maxPooledFields is the largest number of fields an entry can have and
+	// still be put back into the pool.
+	maxPooledFields = 64
+)
+
+var (
+	bufferPool = sync.Pool{
+		New: func() interface{} {
+			return bytes.NewBuffer(make([]byte, 0, 512))
+		},
+	}
+
+	entryPool = sync.Pool{
+		New: func() interface{} {
+			return &Entry{
+				Fields: make([]Field, 0, 8),
+			}
+		},
+	}
+)
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+func getEntry() *Entry {
+	return entryPool.Get().(*Entry)
+}
+
+func putEntry(entry *Entry) {
+	if cap(entry.Fields) > maxPooledFields {
+		return
+	}
+	// Clear the fields so that the pool does not keep the values alive.
+	fields := entry.Fields
+	for i := range fields {
+		fields[i] = Field{}
+	}
+	*entry = Entry{
+		Fields: fields[:0],
+	}
+	entryPool.Put(entry)
+}
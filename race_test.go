@@ -0,0 +1,10 @@
+//go:build race
+// +build race
+
+package timber
+
+func init() {
+	// sync.Pool randomly drops items when the race detector is enabled, so
+	// allocation counts cannot be asserted.
+	raceEnabled = true
+}
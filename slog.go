@@ -0,0 +1,294 @@
+//go:build go1.21
+// +build go1.21
+
+package timber
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+// SlogOptions changes how levels are converted between timber and log/slog.
+type SlogOptions struct {
+	// ToLevel will convert the level of a slog record into a timber level. If
+	// it is nil then LevelFromSlog is used.
+	ToLevel func(lvl slog.Level) Level
+
+	// ToSlogLevel will convert a timber level into the level of a slog record.
+	// If it is nil then LevelToSlog is used.
+	ToSlogLevel func(lvl Level) slog.Level
+}
+
+func (o *SlogOptions) toLevel(lvl slog.Level) Level {
+	if o != nil && o.ToLevel != nil {
+		return o.ToLevel(lvl)
+	}
+	return LevelFromSlog(lvl)
+}
+
+func (o *SlogOptions) toSlogLevel(lvl Level) slog.Level {
+	if o != nil && o.ToSlogLevel != nil {
+		return o.ToSlogLevel(lvl)
+	}
+	return LevelToSlog(lvl)
+}
+
+// LevelFromSlog will convert a slog level into the closest timber level. The
+// slog levels are 4 apart, so every level in between is mapped onto one of the
+// extra timber levels.
+//
+//	below LevelDebug-4 (-8)  Level_Trace
+//	below LevelDebug   (-4)  Level_Verbose
+//	below LevelInfo     (0)  Level_Debug
+//	below LevelWarn     (4)  Level_Info
+//	below LevelError    (8)  Level_Warning
+//	below LevelError+4 (12)  Level_Error
+//	below LevelError+8 (16)  Level_Critical
+//	anything else            Level_Fatal
+func LevelFromSlog(lvl slog.Level) Level {
+	switch {
+	case lvl <= slog.LevelDebug-4:
+		return Level_Trace
+	case lvl < slog.LevelDebug:
+		return Level_Verbose
+	case lvl < slog.LevelInfo:
+		return Level_Debug
+	case lvl < slog.LevelWarn:
+		return Level_Info
+	case lvl < slog.LevelError:
+		return Level_Warning
+	case lvl < slog.LevelError+4:
+		return Level_Error
+	case lvl < slog.LevelError+8:
+		return Level_Critical
+	default:
+		return Level_Fatal
+	}
+}
+
+// LevelToSlog will convert a timber level into a slog level, it is the inverse
+// of LevelFromSlog.
+func LevelToSlog(lvl Level) slog.Level {
+	switch lvl {
+	case Level_Trace:
+		return slog.LevelDebug - 4
+	case Level_Verbose:
+		return slog.LevelDebug - 2
+	case Level_Debug:
+		return slog.LevelDebug
+	case Level_Info:
+		return slog.LevelInfo
+	case Level_Warning:
+		return slog.LevelWarn
+	case Level_Error:
+		return slog.LevelError
+	case Level_Critical:
+		return slog.LevelError + 4
+	case Level_Fatal:
+		return slog.LevelError + 8
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// slogHandler renders slog records through a timber logger.
+type slogHandler struct {
+	logger  *logger
+	options *SlogOptions
+
+	// fields are the attributes added via WithAttrs, already prefixed with
+	// the groups they were added in.
+	fields []Field
+
+	// group is the prefix added to the keys of attributes, it is every group
+	// opened via WithGroup followed by a dot.
+	group string
+}
+
+// NewSlogHandler will create a slog.Handler that writes records through the
+// logger provided, using its level, output, formatter, prefix and keys. If the
+// logger is nil then the global logger is used. Attributes in groups are
+// written with their keys joined by dots. Records are never terminal, so a
+// record at Level_Fatal will not exit the program.
+//
+//	slog.SetDefault(slog.New(timber.NewSlogHandler(timber.New(), nil)))
+func NewSlogHandler(l Logger, options *SlogOptions) slog.Handler {
+	lg, ok := l.(*logger)
+	if !ok {
+		lg = defaultLogger
+	}
+	return &slogHandler{
+		logger:  lg,
+		options: options,
+	}
+}
+
+// Enabled will return true if the logger will write records of the level
+// provided.
+func (h *slogHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return h.logger.shouldLog(h.options.toLevel(lvl))
+}
+
+// Handle will write the record to the logger.
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	entry := getEntry()
+	defer putEntry(entry)
+	entry.Level = h.options.toLevel(r.Level)
+	entry.Time = r.Time
+	if entry.Time.IsZero() {
+		entry.Time = now()
+	}
+	if settings := h.logger.getCallerSettings(); settings.mode != CallerMode_Off {
+		entry.Caller = "unknown:0"
+		if r.PC != 0 {
+			frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+			if len(frame.File) > 0 {
+				entry.Caller = formatCallerMode(settings.mode, frame.Function, frame.File, frame.Line)
+				if settings.function {
+					entry.Function = shortFunctionName(frame.Function)
+				}
+			}
+		}
+	}
+	callSite := make([]Field, 0, r.NumAttrs()+len(h.fields))
+	r.Attrs(func(attr slog.Attr) bool {
+		callSite = appendSlogAttr(callSite, h.group, attr)
+		return true
+	})
+	callSite = append(callSite, h.fields...)
+	entry.Fields = h.logger.appendFields(entry.Fields, nil, callSite)
+	entry.Message = r.Message
+	h.logger.writeEntry(entry)
+	return nil
+}
+
+// WithAttrs will return a handler that writes the attributes provided with
+// every record.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	handler := *h
+	handler.fields = make([]Field, 0, len(h.fields)+len(attrs))
+	for _, attr := range attrs {
+		handler.fields = appendSlogAttr(handler.fields, h.group, attr)
+	}
+	handler.fields = append(handler.fields, h.fields...)
+	return &handler
+}
+
+// WithGroup will return a handler that prefixes the keys of every attribute
+// added after it with the name of the group.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+	handler := *h
+	handler.group = h.group + name + "."
+	return &handler
+}
+
+// appendSlogAttr will convert the attribute into fields and append them to the
+// fields provided. Groups are flattened into one field per attribute.
+func appendSlogAttr(fields []Field, group string, attr slog.Attr) []Field {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		attrs := value.Group()
+		if len(attrs) == 0 {
+			return fields
+		}
+		if len(attr.Key) > 0 {
+			group += attr.Key + "."
+		}
+		for _, a := range attrs {
+			fields = appendSlogAttr(fields, group, a)
+		}
+		return fields
+	}
+	if len(attr.Key) == 0 && value.Any() == nil {
+		return fields
+	}
+	key := group + attr.Key
+	switch value.Kind() {
+	case slog.KindString:
+		return append(fields, String(key, value.String()))
+	case slog.KindInt64:
+		return append(fields, Int64(key, value.Int64()))
+	case slog.KindUint64:
+		return append(fields, Uint64(key, value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, Float64(key, value.Float64()))
+	case slog.KindBool:
+		return append(fields, Bool(key, value.Bool()))
+	case slog.KindDuration:
+		return append(fields, Duration(key, value.Duration()))
+	case slog.KindTime:
+		return append(fields, Time(key, value.Time()))
+	default:
+		return append(fields, Any(key, value.Any()))
+	}
+}
+
+// slogEntryHandler hands the entries of a timber logger to a slog.Handler.
+type slogEntryHandler struct {
+	handler slog.Handler
+	options *SlogOptions
+}
+
+// NewSlogLogger will create a Logger that hands every entry to the slog.Handler
+// provided instead of writing it. The level of the Logger is checked first,
+// followed by the handler. The name and prefix of the logger are added to each
+// record as the logger and prefix attributes. Terminal levels still exit or
+// panic once the handler has returned.
+//
+//	log := timber.NewSlogLogger(slog.NewJSONHandler(os.Stderr, nil), nil)
+func NewSlogLogger(h slog.Handler, options *SlogOptions) Logger {
+	return &logger{
+		stackDepth: defaultStackDepth,
+		handler: &slogEntryHandler{
+			handler: h,
+			options: options,
+		},
+	}
+}
+
+func (h *slogEntryHandler) enabled(lvl Level) bool {
+	return h.handler.Enabled(context.Background(), h.options.toSlogLevel(lvl))
+}
+
+func (h *slogEntryHandler) handle(pc uintptr, entry *Entry) {
+	r := slog.NewRecord(entry.Time, h.options.toSlogLevel(entry.Level), entry.Message, pc)
+	if len(entry.Name) > 0 {
+		r.AddAttrs(slog.String("logger", entry.Name))
+	}
+	if len(entry.Prefix) > 0 {
+		r.AddAttrs(slog.String("prefix", entry.Prefix))
+	}
+	for _, field := range entry.Fields {
+		r.AddAttrs(fieldToSlogAttr(field))
+	}
+	_ = h.handler.Handle(context.Background(), r)
+}
+
+// fieldToSlogAttr will convert a field into a slog attribute without boxing
+// typed fields in an interface.
+func fieldToSlogAttr(field Field) slog.Attr {
+	switch field.Type {
+	case FieldType_String:
+		return slog.String(field.Key, field.String)
+	case FieldType_Int:
+		return slog.Int64(field.Key, field.Integer)
+	case FieldType_Uint:
+		return slog.Uint64(field.Key, uint64(field.Integer))
+	case FieldType_Bool:
+		return slog.Bool(field.Key, field.Integer == 1)
+	case FieldType_Duration:
+		return slog.Duration(field.Key, time.Duration(field.Integer))
+	case FieldType_Time:
+		return slog.Time(field.Key, field.time())
+	default:
+		return slog.Any(field.Key, field.Interface())
+	}
+}
@@ -0,0 +1,199 @@
+//go:build go1.21
+// +build go1.21
+
+package timber
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLevelFromSlog(t *testing.T) {
+	for lvl, expected := range map[slog.Level]Level{
+		slog.LevelDebug - 8: Level_Trace,
+		slog.LevelDebug - 4: Level_Trace,
+		slog.LevelDebug - 2: Level_Verbose,
+		slog.LevelDebug:     Level_Debug,
+		slog.LevelInfo:      Level_Info,
+		slog.LevelInfo + 1:  Level_Info,
+		slog.LevelWarn:      Level_Warning,
+		slog.LevelError:     Level_Error,
+		slog.LevelError + 4: Level_Critical,
+		slog.LevelError + 8: Level_Fatal,
+		slog.LevelError + 9: Level_Fatal,
+	} {
+		assert.Equal(t, expected, LevelFromSlog(lvl), lvl.String())
+	}
+
+	for _, lvl := range []Level{
+		Level_Trace, Level_Verbose, Level_Debug, Level_Info,
+		Level_Warning, Level_Error, Level_Critical, Level_Fatal,
+	} {
+		assert.Equal(t, lvl, LevelFromSlog(LevelToSlog(lvl)), lvl.String())
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("text", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		lg := New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true}).
+			Prefix("prefix").
+			With(Keys{"service": "api", "user": "inherited"})
+		log := slog.New(NewSlogHandler(lg, nil))
+		log.Warn("test", "user", "bob", "id", 1)
+		assert.Contains(t, buf.String(), "[WARN] [prefix] ")
+		assert.Contains(t, buf.String(), "slog_test.go:")
+		assert.True(t, strings.HasSuffix(buf.String(), " { user: bob, id: 1, service: api } | test\n"), buf.String())
+	})
+
+	t.Run("attrs and groups", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		tm := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+		lg := New().SetOutput(buf).SetFormatter(&JSONFormatter{})
+		log := slog.New(NewSlogHandler(lg, nil)).
+			With("request", 1).
+			WithGroup("http").
+			With("method", "GET")
+		log.Error("failed",
+			slog.Group("response", slog.Int("status", 500), slog.Duration("took", time.Second)),
+			slog.Group("", slog.Bool("inline", true)),
+			slog.Group("empty"),
+			slog.Time("at", tm),
+			slog.Float64("ratio", 0.5),
+			slog.Any("error", errors.New("bad")),
+			slog.Any("lazy", slog.StringValue("resolved")),
+		)
+
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, "Error", obj["level"])
+		assert.Equal(t, "failed", obj["msg"])
+		assert.Equal(t, float64(1), obj["request"])
+		assert.Equal(t, "GET", obj["http.method"])
+		assert.Equal(t, float64(500), obj["http.response.status"])
+		assert.Equal(t, "1s", obj["http.response.took"])
+		assert.Equal(t, true, obj["http.inline"])
+		assert.Equal(t, "2019-05-01T12:00:00Z", obj["http.at"])
+		assert.Equal(t, 0.5, obj["http.ratio"])
+		assert.Equal(t, "bad", obj["http.error"])
+		assert.Equal(t, "resolved", obj["http.lazy"])
+		assert.NotContains(t, obj, "http.empty")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		lg := New().SetOutput(buf).SetLevel(Level_Info)
+		handler := NewSlogHandler(lg, nil)
+		assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+		assert.True(t, handler.Enabled(context.Background(), slog.LevelInfo))
+		slog.New(handler).Debug("skipped")
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("custom levels", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		lg := New().SetOutput(buf).SetColor(false)
+		log := slog.New(NewSlogHandler(lg, &SlogOptions{
+			ToLevel: func(lvl slog.Level) Level {
+				return Level_Critical
+			},
+		}))
+		log.Info("test")
+		assert.Contains(t, buf.String(), "[CRIT]")
+	})
+}
+
+type recordHandler struct {
+	level   slog.Level
+	records []slog.Record
+}
+
+func (h *recordHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return lvl >= h.level
+}
+
+func (h *recordHandler) Handle(_ context.Context, r slog.Record) error {
+	h.records = append(h.records, r.Clone())
+	return nil
+}
+
+func (h *recordHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h
+}
+
+func (h *recordHandler) WithGroup(name string) slog.Handler {
+	return h
+}
+
+func recordAttrs(r slog.Record) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	r.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value.Any()
+		return true
+	})
+	return attrs
+}
+
+func TestSlogLogger(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("records", func(t *testing.T) {
+		h := &recordHandler{level: slog.LevelInfo}
+		log := NewSlogLogger(h, nil).Prefix("prefix").With(Keys{"service": "api"})
+		log.Debug("skipped")
+		log.Warningw("test", String("user", "bob"), Int("id", 1), Bool("ok", true))
+		if assert.Len(t, h.records, 1) {
+			r := h.records[0]
+			assert.Equal(t, slog.LevelWarn, r.Level)
+			assert.Equal(t, "test", r.Message)
+			assert.False(t, r.Time.IsZero())
+			assert.Equal(t, map[string]interface{}{
+				"prefix":  "prefix",
+				"user":    "bob",
+				"id":      int64(1),
+				"ok":      true,
+				"service": "api",
+			}, recordAttrs(r))
+			frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+			assert.Contains(t, frame.File, "slog_test.go")
+		}
+	})
+
+	t.Run("logger level", func(t *testing.T) {
+		h := &recordHandler{level: slog.LevelDebug - 8}
+		log := NewSlogLogger(h, nil).SetLevel(Level_Error)
+		log.Warning("skipped")
+		log.Error("written")
+		assert.Len(t, h.records, 1)
+	})
+
+	t.Run("custom levels", func(t *testing.T) {
+		h := &recordHandler{level: slog.LevelDebug - 8}
+		log := NewSlogLogger(h, &SlogOptions{
+			ToSlogLevel: func(lvl Level) slog.Level {
+				return slog.Level(lvl)
+			},
+		})
+		log.Verbose("test")
+		if assert.Len(t, h.records, 1) {
+			assert.Equal(t, slog.Level(Level_Verbose), h.records[0].Level)
+		}
+	})
+
+	t.Run("fatal", func(t *testing.T) {
+		defer expectExit(t)()
+		h := &recordHandler{}
+		NewSlogLogger(h, nil).Fatal("test")
+		assert.Len(t, h.records, 1)
+	})
+}
@@ -0,0 +1,274 @@
+package timber
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// StackMode changes which goroutines have their stack captured when an entry is
+// written at or above the stack level of a logger, see SetStack.
+type StackMode int
+
+const (
+	// StackMode_Off will not capture any stacks.
+	StackMode_Off StackMode = iota + 1
+
+	// StackMode_Goroutine will capture the stack of the goroutine that wrote
+	// the entry.
+	StackMode_Goroutine
+
+	// StackMode_All will capture the stack of every goroutine, the goroutine
+	// that wrote the entry is always first.
+	StackMode_All
+)
+
+const (
+	// defaultStackLevel is the minimum level that stacks are captured at when
+	// they are enabled.
+	defaultStackLevel = Level_Critical
+
+	// initialStackSize is the size of the buffer that stacks are first read
+	// into, it is doubled until the whole stack fits.
+	initialStackSize = 4 << 10
+)
+
+// StackFrame is a single function call in a captured stack.
+type StackFrame struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Goroutine is the captured stack of a single goroutine, the frames start with
+// the most recent call.
+type Goroutine struct {
+	ID     int
+	State  string
+	Frames []StackFrame
+}
+
+// stackSettings is the level and mode that stacks are captured with, if the mode
+// is 0 then the settings are inherited.
+type stackSettings struct {
+	level Level
+	mode  StackMode
+}
+
+var (
+	stackCapture = stackSettings{
+		level: defaultStackLevel,
+		mode:  StackMode_Off,
+	}
+	stackCaptureLock sync.RWMutex
+)
+
+// SetStack will make every logger that has not had its own stack settings
+// changed via Logger.SetStack capture stacks for entries at or above the level
+// provided. The mode decides whether the stack of only the goroutine that wrote
+// the entry or the stack of every goroutine is captured. By default stacks are
+// not captured, and setting the mode to 0 or StackMode_Off will turn it off
+// again.
+//
+//	timber.SetStack(timber.Level_Critical, timber.StackMode_Goroutine)
+func SetStack(lvl Level, mode StackMode) {
+	if mode == 0 {
+		mode = StackMode_Off
+	}
+	stackCaptureLock.Lock()
+	defer stackCaptureLock.Unlock()
+	stackCapture = stackSettings{
+		level: lvl,
+		mode:  mode,
+	}
+}
+
+// SetStack will capture stacks for entries at or above the level provided that
+// are written by this logger and any loggers created from it via With. The mode
+// decides whether only the goroutine that wrote the entry or every goroutine is
+// captured. Setting the mode to 0 will make the logger inherit the stack
+// settings again.
+func (l *logger) SetStack(lvl Level, mode StackMode) Logger {
+	l.stackLock.Lock()
+	defer l.stackLock.Unlock()
+	l.stack = stackSettings{
+		level: lvl,
+		mode:  mode,
+	}
+	return l
+}
+
+func (l *logger) getStackSettings() stackSettings {
+	for lg := l; lg != nil; lg = lg.parent {
+		lg.stackLock.RLock()
+		settings := lg.stack
+		lg.stackLock.RUnlock()
+		if settings.mode != 0 {
+			return settings
+		}
+	}
+	stackCaptureLock.RLock()
+	defer stackCaptureLock.RUnlock()
+	return stackCapture
+}
+
+// captureStack will return the stack of the current goroutine, followed by the
+// stacks of every other goroutine if all is true. The number of frames to skip
+// is counted the same way as CallerInfo, 0 is captureStack itself.
+func captureStack(skip int, all bool) []Goroutine {
+	buf := make([]byte, initialStackSize)
+	for {
+		n := runtime.Stack(buf, all)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, len(buf)*2)
+	}
+	goroutines := parseStack(buf)
+	if len(goroutines) > 0 && skip < len(goroutines[0].Frames) {
+		goroutines[0].Frames = goroutines[0].Frames[skip:]
+	}
+	return goroutines
+}
+
+// parseStack will parse the output of runtime.Stack. The function that created
+// each goroutine is not included in its frames.
+func parseStack(b []byte) []Goroutine {
+	goroutines := make([]Goroutine, 0, 1)
+	var current *Goroutine
+	createdBy := false
+	for _, line := range bytes.Split(b, []byte("\n")) {
+		switch {
+		case len(line) == 0:
+			current = nil
+		case bytes.HasPrefix(line, []byte("goroutine ")):
+			goroutines = append(goroutines, parseGoroutineHeader(line))
+			current = &goroutines[len(goroutines)-1]
+		case current == nil:
+			continue
+		case line[0] == '\t':
+			if createdBy || len(current.Frames) == 0 {
+				continue
+			}
+			frame := &current.Frames[len(current.Frames)-1]
+			frame.File, frame.Line = parseStackLocation(line[1:])
+		case bytes.HasPrefix(line, []byte("created by ")):
+			createdBy = true
+		default:
+			createdBy = false
+			current.Frames = append(current.Frames, StackFrame{
+				Function: parseStackFunction(line),
+			})
+		}
+	}
+	return goroutines
+}
+
+// parseGoroutineHeader will parse a line like "goroutine 1 [running]:".
+func parseGoroutineHeader(line []byte) Goroutine {
+	line = bytes.TrimPrefix(line, []byte("goroutine "))
+	g := Goroutine{}
+	if end := bytes.IndexByte(line, ' '); end > 0 {
+		g.ID, _ = strconv.Atoi(string(line[:end]))
+	}
+	if start, end := bytes.IndexByte(line, '['), bytes.LastIndexByte(line, ']'); start >= 0 && end > start {
+		g.State = string(line[start+1 : end])
+	}
+	return g
+}
+
+// parseStackFunction will remove the arguments from a line like
+// "main.(*Type).Method(0x1, 0x2)".
+func parseStackFunction(line []byte) string {
+	if bytes.HasSuffix(line, []byte(")")) {
+		if start := bytes.LastIndexByte(line, '('); start > 0 {
+			line = line[:start]
+		}
+	}
+	return string(line)
+}
+
+// parseStackLocation will parse a line like "/src/main.go:12 +0x1d" into the
+// file and line number.
+func parseStackLocation(line []byte) (string, int) {
+	if end := bytes.LastIndexByte(line, ' '); end > 0 {
+		line = line[:end]
+	}
+	end := bytes.LastIndexByte(line, ':')
+	if end < 0 {
+		return string(line), 0
+	}
+	n, _ := strconv.Atoi(string(line[end+1:]))
+	return string(line[:end]), n
+}
+
+// writeTextStack will write every goroutine below the entry in the same layout
+// that runtime.Stack uses, with each line indented.
+func writeTextStack(buf *bytes.Buffer, goroutines []Goroutine) {
+	var tmp [64]byte
+	for _, g := range goroutines {
+		buf.WriteString("\tgoroutine ")
+		buf.Write(strconv.AppendInt(tmp[:0], int64(g.ID), 10))
+		buf.WriteString(" [")
+		buf.WriteString(g.State)
+		buf.WriteString("]:\n")
+		for _, frame := range g.Frames {
+			buf.WriteByte('\t')
+			buf.WriteString(frame.Function)
+			buf.WriteString("()\n\t\t")
+			buf.WriteString(frame.File)
+			buf.WriteByte(':')
+			buf.Write(strconv.AppendInt(tmp[:0], int64(frame.Line), 10))
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// writeJSONStack will write the frames of the goroutine that wrote the entry as
+// the stack field. Any other goroutines are written to the goroutines field
+// along with their ID and state.
+func writeJSONStack(buf *bytes.Buffer, goroutines []Goroutine) {
+	if len(goroutines) == 0 {
+		return
+	}
+	buf.WriteString(`,"stack":`)
+	writeJSONFrames(buf, goroutines[0].Frames)
+	if len(goroutines) == 1 {
+		return
+	}
+	var tmp [64]byte
+	buf.WriteString(`,"goroutines":[`)
+	for i, g := range goroutines[1:] {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"id":`)
+		buf.Write(strconv.AppendInt(tmp[:0], int64(g.ID), 10))
+		buf.WriteString(`,"state":`)
+		writeJSONString(buf, g.State)
+		buf.WriteString(`,"stack":`)
+		writeJSONFrames(buf, g.Frames)
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+}
+
+func writeJSONFrames(buf *bytes.Buffer, frames []StackFrame) {
+	var tmp [64]byte
+	buf.WriteByte('[')
+	for i, frame := range frames {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(`{"function":`)
+		writeJSONString(buf, frame.Function)
+		buf.WriteString(`,"file":`)
+		writeJSONString(buf, frame.File)
+		buf.WriteString(`,"line":`)
+		buf.Write(strconv.AppendInt(tmp[:0], int64(frame.Line), 10))
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+}
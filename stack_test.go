@@ -0,0 +1,167 @@
+package timber
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testStack = `goroutine 7 [running]:
+github.com/elliotcourant/timber.(*logger).Critical(0xc000010000, {0x5a0ee0, 0xc000012345})
+	/src/timber/levels.go:640 +0x65
+main.main()
+	/src/main.go:12 +0x1d
+
+goroutine 18 [chan receive, 2 minutes]:
+main.worker(...)
+	/src/worker.go:30
+created by main.main in goroutine 1
+	/src/main.go:10 +0x2a
+`
+
+func TestParseStack(t *testing.T) {
+	assert.Equal(t, []Goroutine{
+		{
+			ID:    7,
+			State: "running",
+			Frames: []StackFrame{
+				{Function: "github.com/elliotcourant/timber.(*logger).Critical", File: "/src/timber/levels.go", Line: 640},
+				{Function: "main.main", File: "/src/main.go", Line: 12},
+			},
+		},
+		{
+			ID:    18,
+			State: "chan receive, 2 minutes",
+			Frames: []StackFrame{
+				{Function: "main.worker", File: "/src/worker.go", Line: 30},
+			},
+		},
+	}, parseStack([]byte(testStack)))
+}
+
+func TestLogger_SetStack(t *testing.T) {
+	SetLevel(Level_Trace)
+	buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+	log := New().SetOutput(buf).SetFormatter(f)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		f.entries = nil
+		log.Critical("test")
+		if assert.Len(t, f.entries, 1) {
+			assert.Empty(t, f.entries[0].Stack)
+		}
+	})
+
+	t.Run("goroutine", func(t *testing.T) {
+		f.entries = nil
+		log.SetStack(Level_Critical, StackMode_Goroutine)
+		defer log.SetStack(0, 0)
+		log.Error("below the level")
+		log.Critical("test")
+		log.With(Keys{"a": 1}).Critical("inherited")
+		if assert.Len(t, f.entries, 3) {
+			assert.Empty(t, f.entries[0].Stack)
+			for _, entry := range f.entries[1:] {
+				if assert.Len(t, entry.Stack, 1) {
+					assert.Equal(t, "running", entry.Stack[0].State)
+					frame := entry.Stack[0].Frames[0]
+					assert.Equal(t, "github.com/elliotcourant/timber.TestLogger_SetStack.func2", frame.Function)
+					assert.Equal(t, entry.Caller, formatCaller(frame.File, frame.Line))
+				}
+			}
+		}
+	})
+
+	t.Run("all", func(t *testing.T) {
+		f.entries = nil
+		SetStack(Level_Error, StackMode_All)
+		defer SetStack(0, 0)
+		done, started := make(chan struct{}), make(chan struct{})
+		go func() {
+			close(started)
+			<-done
+		}()
+		defer close(done)
+		<-started
+		log.Warning("below the level")
+		log.With(Keys{"a": 1}).SetStack(Level_Critical, StackMode_Off).Error("disabled")
+		log.Error("test")
+		if assert.Len(t, f.entries, 3) {
+			assert.Empty(t, f.entries[0].Stack)
+			assert.Empty(t, f.entries[1].Stack)
+			stack := f.entries[2].Stack
+			if assert.True(t, len(stack) > 1) {
+				assert.Equal(t, "github.com/elliotcourant/timber.TestLogger_SetStack.func3", stack[0].Frames[0].Function)
+				found := false
+				for _, g := range stack[1:] {
+					for _, frame := range g.Frames {
+						found = found || strings.HasPrefix(frame.Function, "github.com/elliotcourant/timber.TestLogger_SetStack.func3.")
+					}
+				}
+				assert.True(t, found, "the blocked goroutine should be captured")
+			}
+		}
+	})
+}
+
+func TestStack_Format(t *testing.T) {
+	entry := &Entry{
+		Level:   Level_Critical,
+		Time:    time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+		Caller:  "main.go:12",
+		Message: "test",
+		Stack:   parseStack([]byte(testStack)),
+	}
+
+	t.Run("text", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&TextFormatter{DisableTime: true}).Format(buf, entry))
+		assert.Equal(t, "[CRIT] main.go:12 test\n"+
+			"\tgoroutine 7 [running]:\n"+
+			"\tgithub.com/elliotcourant/timber.(*logger).Critical()\n"+
+			"\t\t/src/timber/levels.go:640\n"+
+			"\tmain.main()\n"+
+			"\t\t/src/main.go:12\n"+
+			"\tgoroutine 18 [chan receive, 2 minutes]:\n"+
+			"\tmain.worker()\n"+
+			"\t\t/src/worker.go:30\n", buf.String())
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&JSONFormatter{}).Format(buf, entry))
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"function": "github.com/elliotcourant/timber.(*logger).Critical", "file": "/src/timber/levels.go", "line": float64(640)},
+			map[string]interface{}{"function": "main.main", "file": "/src/main.go", "line": float64(12)},
+		}, obj["stack"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{
+				"id":    float64(18),
+				"state": "chan receive, 2 minutes",
+				"stack": []interface{}{
+					map[string]interface{}{"function": "main.worker", "file": "/src/worker.go", "line": float64(30)},
+				},
+			},
+		}, obj["goroutines"])
+	})
+
+	t.Run("json reserved keys", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&JSONFormatter{}).Format(buf, &Entry{
+			Level:   Level_Critical,
+			Message: "test",
+			Fields:  []Field{String("stack", "user"), String("goroutines", "user")},
+			Stack:   entry.Stack[:1],
+		}))
+		var obj map[string]interface{}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &obj))
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte(`"stack"`)), buf.String())
+		assert.Equal(t, "user", obj["fields.stack"])
+		assert.Equal(t, "user", obj["goroutines"], "no other goroutines were captured")
+	})
+}
@@ -0,0 +1,179 @@
+package timber
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// StdLogOptions changes how lines written by the standard library's log
+// package are turned into entries.
+type StdLogOptions struct {
+	// Level is the level that each line is written at, if it is 0 then
+	// Level_Info is used.
+	Level Level
+
+	// ParseLevel will look for a level at the start of each line, like
+	// "[WARN] message" or "error: message". When one is found it is removed
+	// from the message and the entry is written at that level instead. Any
+	// name or short name accepted by ParseLevel can be used. Levels that would
+	// exit or panic once the entry is written, like "fatal:", are written as
+	// Level_Error instead, so that the text of a line cannot stop the program.
+	ParseLevel bool
+}
+
+// maxStdLineSize is the most bytes that the stdWriter will hold while waiting
+// for the end of a line, anything longer is written as an entry of its own.
+const maxStdLineSize = 64 << 10
+
+// stdWriter forwards each line written to it to a timber logger as an entry.
+type stdWriter struct {
+	logger  Logger
+	options StdLogOptions
+
+	// buf holds the start of a line that has not been finished yet.
+	buf     []byte
+	bufLock sync.Mutex
+}
+
+// NewStdWriter will create a writer that writes everything written to it to
+// the logger provided. Each line is a single entry, so a message with multiple
+// lines is written as multiple entries. A write that does not end with a
+// newline is held until the rest of the line is written. The caller of each
+// entry is the code that called into the log or fmt package, rather than the
+// writer itself.
+func NewStdWriter(l Logger, options StdLogOptions) io.Writer {
+	if options.Level == 0 {
+		options.Level = Level_Info
+	}
+	return &stdWriter{
+		logger:  l,
+		options: options,
+	}
+}
+
+// NewStdLogger will create a *log.Logger from the standard library that writes
+// each message to the logger provided. This can be given to libraries that only
+// accept a *log.Logger.
+//
+//	server := &http.Server{
+//		ErrorLog: timber.NewStdLogger(log, timber.StdLogOptions{Level: timber.Level_Error}),
+//	}
+func NewStdLogger(l Logger, options StdLogOptions) *log.Logger {
+	return log.New(NewStdWriter(l, options), "", 0)
+}
+
+// RedirectStdLog will make the standard library's global logger write to the
+// logger provided. Its flags and prefix are cleared since timber writes the
+// time and caller itself. The function returned will restore the output, flags
+// and prefix of the global logger.
+func RedirectStdLog(l Logger, options StdLogOptions) func() {
+	flags, prefix, output := log.Flags(), log.Prefix(), log.Writer()
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(NewStdWriter(l, options))
+	return func() {
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+		log.SetOutput(output)
+	}
+}
+
+// Write will write each line in the bytes provided to the logger as an entry,
+// the last line is held until it is finished if it does not end in a newline.
+func (w *stdWriter) Write(p []byte) (int, error) {
+	w.bufLock.Lock()
+	defer w.bufLock.Unlock()
+	w.buf = append(w.buf, p...)
+	rest := w.buf
+	for {
+		end := bytes.IndexByte(rest, '\n')
+		if end < 0 {
+			break
+		}
+		w.writeLine(rest[:end])
+		rest = rest[end+1:]
+	}
+	if len(rest) >= maxStdLineSize {
+		w.writeLine(rest)
+		rest = nil
+	}
+	w.buf = append(w.buf[:0], rest...)
+	return len(p), nil
+}
+
+// writeLine will write a single line to the logger as an entry.
+func (w *stdWriter) writeLine(line []byte) {
+	msg := string(bytes.TrimRight(line, "\r"))
+	lvl := w.options.Level
+	if w.options.ParseLevel {
+		if parsed, rest, ok := parseLinePrefix(msg); ok {
+			lvl, msg = parsed, rest
+			if terminalActions[lvl] != terminalAction_None {
+				lvl = Level_Error
+			}
+		}
+	}
+	if lg, ok := w.logger.(*logger); ok {
+		lg.log(stdCallerDepth(), lvl, nil, nil, msg)
+	} else {
+		w.logger.Log(lvl, msg)
+	}
+}
+
+// stdCallerDepth will return the stack depth that the caller of an entry
+// written by the stdWriter is at, it is the first frame that is not in the log
+// or fmt packages.
+func stdCallerDepth() int {
+	var pcs [16]uintptr
+	// Skip runtime.Callers, this function, stdWriter.writeLine and
+	// stdWriter.Write.
+	n := runtime.Callers(4, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for i := 0; ; i++ {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "log.") && !strings.HasPrefix(frame.Function, "fmt.") {
+			// The caller is found from logger.logContext, which is called
+			// from logger.log with one more frame than it is given, which is
+			// called from stdWriter.writeLine.
+			return i + 4
+		}
+		if !more {
+			return 4
+		}
+	}
+}
+
+// parseLinePrefix will parse a level from the start of the line provided, in
+// the form "[LEVEL] message" or "LEVEL: message". The level and the rest of the
+// message are returned.
+func parseLinePrefix(line string) (Level, string, bool) {
+	var name, rest string
+	switch {
+	case strings.HasPrefix(line, "["):
+		end := strings.IndexByte(line, ']')
+		if end < 0 {
+			return 0, line, false
+		}
+		name, rest = line[1:end], line[end+1:]
+	default:
+		end := strings.IndexByte(line, ':')
+		if end < 0 {
+			return 0, line, false
+		}
+		name, rest = line[:end], line[end+1:]
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return 0, line, false
+		}
+	}
+	lvl, err := ParseLevel(name)
+	if err != nil || lvl == 0 {
+		return 0, line, false
+	}
+	return lvl, strings.TrimLeft(rest, " "), true
+}
@@ -0,0 +1,134 @@
+package timber
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestNewStdLogger(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("level", func(t *testing.T) {
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		std := NewStdLogger(New().SetOutput(buf).SetFormatter(f), StdLogOptions{Level: Level_Error})
+		std.Printf("failed %d", 1)
+		std.Print("multiple\nlines\n")
+		if assert.Len(t, f.entries, 3) {
+			assert.Equal(t, Level_Error, f.entries[0].Level)
+			assert.Equal(t, "failed 1", f.entries[0].Message)
+			assert.Contains(t, f.entries[0].Caller, "stdlog_test.go")
+			assert.Equal(t, "multiple", f.entries[1].Message)
+			assert.Equal(t, "lines", f.entries[2].Message)
+			assert.Contains(t, f.entries[2].Caller, "stdlog_test.go")
+		}
+	})
+
+	t.Run("default level", func(t *testing.T) {
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		std := NewStdLogger(New().SetOutput(buf).SetFormatter(f), StdLogOptions{})
+		std.Println("test")
+		if assert.Len(t, f.entries, 1) {
+			assert.Equal(t, Level_Info, f.entries[0].Level)
+		}
+	})
+
+	t.Run("parse level", func(t *testing.T) {
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		std := NewStdLogger(New().SetOutput(buf).SetFormatter(f), StdLogOptions{ParseLevel: true})
+		for _, line := range []string{
+			"[WARN] warning",
+			"error: error",
+			"[Debug]debug",
+			"[unknown] not a level",
+			"http: not a level",
+			"1: not a level",
+			"no level",
+		} {
+			std.Print(line)
+		}
+		levels, messages := make([]Level, 0), make([]string, 0)
+		for _, entry := range f.entries {
+			levels = append(levels, entry.Level)
+			messages = append(messages, entry.Message)
+		}
+		assert.Equal(t, []Level{
+			Level_Warning, Level_Error, Level_Debug, Level_Info, Level_Info, Level_Info, Level_Info,
+		}, levels)
+		assert.Equal(t, []string{
+			"warning", "error", "debug", "[unknown] not a level", "http: not a level", "1: not a level", "no level",
+		}, messages)
+	})
+
+	t.Run("terminal levels", func(t *testing.T) {
+		SetPanicEnabled(true)
+		defer SetPanicEnabled(false)
+		exited := false
+		SetExitFunc(func(int) { exited = true })
+		defer SetExitFunc(nil)
+
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		std := NewStdLogger(New().SetOutput(buf).SetFormatter(f), StdLogOptions{ParseLevel: true})
+		assert.NotPanics(t, func() {
+			std.Print("fatal: not fatal")
+			std.Print("[CRIT] not critical")
+		})
+		assert.False(t, exited)
+		if assert.Len(t, f.entries, 2) {
+			assert.Equal(t, Level_Error, f.entries[0].Level)
+			assert.Equal(t, "not fatal", f.entries[0].Message)
+			assert.Equal(t, Level_Error, f.entries[1].Level)
+			assert.Equal(t, "not critical", f.entries[1].Message)
+		}
+	})
+
+	t.Run("writer", func(t *testing.T) {
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		w := NewStdWriter(New().SetOutput(buf).SetFormatter(f), StdLogOptions{Level: Level_Warning})
+		fmt.Fprintf(w, "written %s\n", "directly")
+		if assert.Len(t, f.entries, 1) {
+			assert.Equal(t, "written directly", f.entries[0].Message)
+			assert.Contains(t, f.entries[0].Caller, "stdlog_test.go")
+		}
+	})
+
+	t.Run("partial writes", func(t *testing.T) {
+		buf, f := bytes.NewBuffer(nil), &entryFormatter{}
+		w := NewStdWriter(New().SetOutput(buf).SetFormatter(f), StdLogOptions{})
+		fmt.Fprint(w, "first ")
+		fmt.Fprint(w, "line\r\nsecond")
+		fmt.Fprint(w, " line\nthird")
+		messages := make([]string, 0)
+		for _, entry := range f.entries {
+			messages = append(messages, entry.Message)
+		}
+		assert.Equal(t, []string{"first line", "second line"}, messages)
+
+		f.entries = nil
+		fmt.Fprint(w, strings.Repeat("a", maxStdLineSize))
+		if assert.Len(t, f.entries, 1) {
+			assert.Equal(t, "third"+strings.Repeat("a", maxStdLineSize), f.entries[0].Message)
+		}
+	})
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	SetLevel(Level_Trace)
+	log.SetPrefix("before: ")
+	defer log.SetPrefix("")
+
+	buf := bytes.NewBuffer(nil)
+	restore := RedirectStdLog(New().SetOutput(buf).SetColor(false).SetFormatter(&TextFormatter{DisableTime: true}),
+		StdLogOptions{ParseLevel: true})
+	log.Printf("[ERRR] redirected %d", 1)
+	restore()
+
+	assert.True(t, strings.HasPrefix(buf.String(), "[ERRR] "), buf.String())
+	assert.Contains(t, buf.String(), "stdlog_test.go:")
+	assert.True(t, strings.HasSuffix(buf.String(), " redirected 1\n"), buf.String())
+	assert.Equal(t, "before: ", log.Prefix())
+	assert.NotEqual(t, 0, log.Flags())
+}
@@ -0,0 +1,395 @@
+package timber
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SyslogFacility is the part of the system that a syslog message came from.
+type SyslogFacility int
+
+const (
+	SyslogFacility_Kern SyslogFacility = iota
+	SyslogFacility_User
+	SyslogFacility_Mail
+	SyslogFacility_Daemon
+	SyslogFacility_Auth
+	SyslogFacility_Syslog
+	SyslogFacility_LPR
+	SyslogFacility_News
+	SyslogFacility_UUCP
+	SyslogFacility_Cron
+	SyslogFacility_AuthPriv
+	SyslogFacility_FTP
+	SyslogFacility_NTP
+	SyslogFacility_Audit
+	SyslogFacility_Alert
+	SyslogFacility_Clock
+	SyslogFacility_Local0
+	SyslogFacility_Local1
+	SyslogFacility_Local2
+	SyslogFacility_Local3
+	SyslogFacility_Local4
+	SyslogFacility_Local5
+	SyslogFacility_Local6
+	SyslogFacility_Local7
+)
+
+// SyslogSeverity is the severity of a syslog message. Each Level is mapped to
+// a severity, Trace, Verbose and Debug are all written as SyslogSeverity_Debug.
+type SyslogSeverity int
+
+const (
+	SyslogSeverity_Emergency SyslogSeverity = iota
+	SyslogSeverity_Alert
+	SyslogSeverity_Critical
+	SyslogSeverity_Error
+	SyslogSeverity_Warning
+	SyslogSeverity_Notice
+	SyslogSeverity_Informational
+	SyslogSeverity_Debug
+)
+
+// SyslogPrefix is which header field the prefix of a logger is written to by
+// the SyslogFormatter.
+type SyslogPrefix int
+
+const (
+	// SyslogPrefix_AppName will write the prefix as the APP-NAME of each
+	// message, when the logger does not have a prefix the AppName of the
+	// formatter is used instead.
+	SyslogPrefix_AppName SyslogPrefix = iota
+
+	// SyslogPrefix_MsgID will write the prefix as the MSGID of each message.
+	SyslogPrefix_MsgID
+)
+
+const (
+	// defaultSyslogID is the SD-ID of the structured data element that fields
+	// are written to. 32473 is the enterprise number reserved for examples.
+	defaultSyslogID = "timber@32473"
+
+	syslogTimeLayout = "2006-01-02T15:04:05.000000Z07:00"
+	syslogNil        = "-"
+)
+
+var (
+	syslogHostname string
+	syslogAppName  string
+	syslogProcID   string
+	syslogOnce     sync.Once
+)
+
+// SeverityForLevel will return the syslog severity that entries of the level
+// provided are written with.
+func SeverityForLevel(lvl Level) SyslogSeverity {
+	if severity, ok := syslogSeverities[lvl]; ok {
+		return severity
+	}
+	return SyslogSeverity_Informational
+}
+
+// SyslogFormatter renders each entry as an RFC 5424 syslog message followed by
+// a newline. The fields of the entry are written as the parameters of a single
+// structured data element. It is meant to be used with a SyslogWriter, which
+// removes the newline and frames each message for the network it is sent over.
+type SyslogFormatter struct {
+	// Facility is the facility of every message. Only the kernel can send
+	// messages with SyslogFacility_Kern, so when it is 0 SyslogFacility_User is
+	// used instead.
+	Facility SyslogFacility
+
+	// Hostname is written as the HOSTNAME of every message, if it is blank then
+	// the hostname of the machine is used.
+	Hostname string
+
+	// AppName is written as the APP-NAME of every message, if it is blank then
+	// the name of the executable is used.
+	AppName string
+
+	// Prefix is which header field the prefix of the logger is written to.
+	Prefix SyslogPrefix
+
+	// StructuredDataID is the SD-ID of the element that fields are written to,
+	// if it is blank then timber@32473 is used.
+	StructuredDataID string
+}
+
+// Format will write the entry to the buffer as a syslog message.
+func (f *SyslogFormatter) Format(buf *bytes.Buffer, entry *Entry) error {
+	syslogOnce.Do(loadSyslogDefaults)
+	var tmp [64]byte
+	facility := f.Facility
+	if facility == SyslogFacility_Kern {
+		facility = SyslogFacility_User
+	}
+	hostname, appName, msgID := f.Hostname, f.AppName, ""
+	if len(hostname) == 0 {
+		hostname = syslogHostname
+	}
+	if len(appName) == 0 {
+		appName = syslogAppName
+	}
+	if len(entry.Prefix) > 0 {
+		switch f.Prefix {
+		case SyslogPrefix_AppName:
+			appName = entry.Prefix
+		case SyslogPrefix_MsgID:
+			msgID = entry.Prefix
+		}
+	}
+
+	buf.WriteByte('<')
+	buf.Write(strconv.AppendInt(tmp[:0], int64(facility)*8+int64(SeverityForLevel(entry.Level)), 10))
+	buf.WriteString(">1 ")
+	if entry.Time.IsZero() {
+		buf.WriteString(syslogNil)
+	} else {
+		buf.Write(entry.Time.AppendFormat(tmp[:0], syslogTimeLayout))
+	}
+	buf.WriteByte(' ')
+	writeSyslogHeader(buf, hostname, 255)
+	buf.WriteByte(' ')
+	writeSyslogHeader(buf, appName, 48)
+	buf.WriteByte(' ')
+	writeSyslogHeader(buf, syslogProcID, 128)
+	buf.WriteByte(' ')
+	writeSyslogHeader(buf, msgID, 32)
+	buf.WriteByte(' ')
+	f.writeStructuredData(buf, entry.Fields)
+	if len(entry.Message) > 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(entry.Message)
+	}
+	buf.WriteByte('\n')
+	return nil
+}
+
+func (f *SyslogFormatter) writeStructuredData(buf *bytes.Buffer, fields []Field) {
+	if len(fields) == 0 {
+		buf.WriteString(syslogNil)
+		return
+	}
+	id := f.StructuredDataID
+	if len(id) == 0 {
+		id = defaultSyslogID
+	}
+	buf.WriteByte('[')
+	writeSyslogName(buf, id)
+	for _, field := range fields {
+		buf.WriteByte(' ')
+		writeSyslogName(buf, field.Key)
+		buf.WriteString(`="`)
+		start := buf.Len()
+		writeTextField(buf, field)
+
+		// The value is escaped after it has been written so that values that do
+		// not need to be escaped, which is almost all of them, are not copied.
+		if i := bytes.IndexAny(buf.Bytes()[start:], `"\]`); i >= 0 {
+			value := append([]byte(nil), buf.Bytes()[start+i:]...)
+			buf.Truncate(start + i)
+			for _, c := range value {
+				if c == '"' || c == '\\' || c == ']' {
+					buf.WriteByte('\\')
+				}
+				buf.WriteByte(c)
+			}
+		}
+		buf.WriteByte('"')
+	}
+	buf.WriteByte(']')
+}
+
+// writeSyslogHeader will write a header field, replacing any characters that
+// are not printable ASCII and truncating it to the maximum length. A blank
+// field is written as a dash.
+func writeSyslogHeader(buf *bytes.Buffer, s string, max int) {
+	if len(s) == 0 {
+		buf.WriteString(syslogNil)
+		return
+	}
+	if len(s) > max {
+		s = s[:max]
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 33 || c > 126 {
+			buf.WriteByte('_')
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+}
+
+// writeSyslogName will write an SD-ID or PARAM-NAME, which are printable ASCII
+// other than '=', ' ', ']' and '"' and at most 32 characters long.
+func writeSyslogName(buf *bytes.Buffer, s string) {
+	if len(s) == 0 {
+		buf.WriteByte('_')
+		return
+	}
+	if len(s) > 32 {
+		s = s[:32]
+	}
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c < 33 || c > 126 || c == '=' || c == ']' || c == '"' {
+			buf.WriteByte('_')
+		} else {
+			buf.WriteByte(c)
+		}
+	}
+}
+
+func loadSyslogDefaults() {
+	syslogHostname, _ = os.Hostname()
+	if len(os.Args) > 0 {
+		syslogAppName = filepath.Base(os.Args[0])
+	}
+	syslogProcID = strconv.Itoa(os.Getpid())
+}
+
+var (
+	// syslogSockets are the paths of the local syslog socket on linux, macOS
+	// and BSD.
+	syslogSockets = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+	// syslogTimeout is how long connecting to the syslog server or writing a
+	// message to it can take. Writes hold the lock of the writer, so a server
+	// that stops responding would otherwise block every logger that uses it.
+	syslogTimeout = 5 * time.Second
+)
+
+// SyslogWriter is an output that sends syslog messages to a syslog server.
+// Each write is sent as a single message without any trailing newline. When
+// connected over a stream like TCP each message is framed by prefixing it with
+// its length, as described in RFC 6587. If a write fails the writer will
+// reconnect and try to write the message once more. Connecting and writing
+// each time out after 5 seconds.
+//
+//	w, err := timber.DialSyslog("udp", "localhost:514")
+//	if err != nil {
+//		panic(err)
+//	}
+//	defer w.Close()
+//	log := timber.New().SetOutput(w).SetFormatter(&timber.SyslogFormatter{})
+type SyslogWriter struct {
+	network string
+	address string
+
+	conn     net.Conn
+	stream   bool
+	closed   bool
+	connLock sync.Mutex
+}
+
+// DialSyslog will connect to a syslog server. The network can be udp, tcp,
+// unix or unixgram. If the network and address are blank then the local syslog
+// socket is used.
+func DialSyslog(network, address string) (*SyslogWriter, error) {
+	w := &SyslogWriter{
+		network: network,
+		address: address,
+	}
+	if err := w.connect(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// connect will open a new connection, the lock must be held by the caller.
+func (w *SyslogWriter) connect() error {
+	if w.conn != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	if len(w.network) > 0 || len(w.address) > 0 {
+		conn, err := net.DialTimeout(w.network, w.address, syslogTimeout)
+		if err != nil {
+			return err
+		}
+		w.conn, w.stream = conn, isStream(w.network)
+		return nil
+	}
+	for _, network := range []string{"unixgram", "unix"} {
+		for _, path := range syslogSockets {
+			if conn, err := net.DialTimeout(network, path, syslogTimeout); err == nil {
+				w.conn, w.stream = conn, isStream(network)
+				return nil
+			}
+		}
+	}
+	return errors.New("timber: could not find the local syslog socket")
+}
+
+// Write will send the bytes provided as a single syslog message.
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	msg := bytes.TrimSuffix(p, []byte{'\n'})
+	w.connLock.Lock()
+	defer w.connLock.Unlock()
+	if w.closed {
+		return 0, os.ErrClosed
+	}
+	if w.conn == nil {
+		if err := w.connect(); err != nil {
+			return 0, err
+		}
+	}
+	if err := w.write(msg); err != nil {
+		if err = w.connect(); err != nil {
+			return 0, err
+		}
+		if err = w.write(msg); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+func (w *SyslogWriter) write(msg []byte) error {
+	if err := w.conn.SetWriteDeadline(time.Now().Add(syslogTimeout)); err != nil {
+		return err
+	}
+	if !w.stream {
+		_, err := w.conn.Write(msg)
+		return err
+	}
+	var tmp [24]byte
+	buf := getBuffer()
+	defer putBuffer(buf)
+	buf.Write(strconv.AppendInt(tmp[:0], int64(len(msg)), 10))
+	buf.WriteByte(' ')
+	buf.Write(msg)
+	_, err := w.conn.Write(buf.Bytes())
+	return err
+}
+
+// isStream will return true if messages sent over the network need to be
+// framed.
+func isStream(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "unix":
+		return true
+	default:
+		return false
+	}
+}
+
+// Close will close the connection to the syslog server. Writes after Close will
+// fail, and the writer is no longer synced by Sync.
+func (w *SyslogWriter) Close() error {
+	forgetSink(w)
+	w.connLock.Lock()
+	defer w.connLock.Unlock()
+	w.closed = true
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
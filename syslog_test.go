@@ -0,0 +1,210 @@
+package timber
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyslogFormatter_Format(t *testing.T) {
+	entry := &Entry{
+		Level:  Level_Warning,
+		Time:   time.Date(2019, 5, 1, 12, 0, 0, 123456789, time.UTC),
+		Caller: "file.go:12",
+		Prefix: "worker",
+		Fields: []Field{
+			String("user", "bob"),
+			Int("id", 1),
+			String("quoted", `a "b" [c\d]`),
+			String("bad key=", "value"),
+		},
+		Message: "test",
+	}
+	syslogOnce.Do(loadSyslogDefaults)
+	procID := strconv.Itoa(os.Getpid())
+
+	t.Run("app name", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&SyslogFormatter{Hostname: "host"}).Format(buf, entry))
+		assert.Equal(t, "<12>1 2019-05-01T12:00:00.123456Z host worker "+procID+
+			` - [timber@32473 user="bob" id="1" quoted="a \"b\" [c\\d\]" bad_key_="value"] test`+"\n", buf.String())
+	})
+
+	t.Run("msg id", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&SyslogFormatter{
+			Facility:         SyslogFacility_Local3,
+			Hostname:         "my host",
+			AppName:          "app",
+			Prefix:           SyslogPrefix_MsgID,
+			StructuredDataID: "app@1234",
+		}).Format(buf, &Entry{
+			Level:   Level_Fatal,
+			Time:    entry.Time,
+			Prefix:  "worker",
+			Message: "test",
+		}))
+		assert.Equal(t, "<153>1 2019-05-01T12:00:00.123456Z my_host app "+procID+" worker - test\n", buf.String())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&SyslogFormatter{}).Format(buf, &Entry{Level: Level_Trace}))
+		assert.True(t, strings.HasPrefix(buf.String(), "<15>1 - "), buf.String())
+		assert.True(t, strings.HasSuffix(buf.String(), " "+syslogAppName+" "+procID+" - -\n"), buf.String())
+	})
+}
+
+func TestSeverityForLevel(t *testing.T) {
+	for lvl, severity := range map[Level]SyslogSeverity{
+		Level_Trace:    SyslogSeverity_Debug,
+		Level_Verbose:  SyslogSeverity_Debug,
+		Level_Debug:    SyslogSeverity_Debug,
+		Level_Info:     SyslogSeverity_Informational,
+		Level_Warning:  SyslogSeverity_Warning,
+		Level_Error:    SyslogSeverity_Error,
+		Level_Critical: SyslogSeverity_Critical,
+		Level_Fatal:    SyslogSeverity_Alert,
+		0:              SyslogSeverity_Informational,
+	} {
+		assert.Equal(t, severity, SeverityForLevel(lvl), lvl.String())
+	}
+}
+
+func TestSyslogWriter(t *testing.T) {
+	SetLevel(Level_Trace)
+	formatter := &SyslogFormatter{Hostname: "host", AppName: "app"}
+
+	t.Run("udp", func(t *testing.T) {
+		conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		w, err := DialSyslog("udp", conn.LocalAddr().String())
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer w.Close()
+
+		New().SetOutput(w).SetFormatter(formatter).InfoEx(Keys{"a": 1}, "over udp")
+		buf := make([]byte, 1024)
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		assert.NoError(t, err)
+		msg := string(buf[:n])
+		assert.True(t, strings.HasPrefix(msg, "<14>1 "), msg)
+		assert.True(t, strings.HasSuffix(msg, ` [timber@32473 a="1"] over udp`), msg)
+	})
+
+	t.Run("tcp", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer listener.Close()
+		w, err := DialSyslog("tcp", listener.Addr().String())
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer w.Close()
+		conn, err := listener.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		log := New().SetOutput(w).SetFormatter(formatter)
+		log.Error("first")
+		log.Error("second\nline")
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		reader := bufio.NewReader(conn)
+		for _, expected := range []string{"first", "second\nline"} {
+			length, err := reader.ReadString(' ')
+			assert.NoError(t, err)
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			assert.NoError(t, err)
+			msg := make([]byte, n)
+			_, err = io.ReadFull(reader, msg)
+			assert.NoError(t, err)
+			assert.True(t, strings.HasPrefix(string(msg), "<11>1 "), string(msg))
+			assert.True(t, strings.HasSuffix(string(msg), " - "+expected), string(msg))
+		}
+
+		assert.NoError(t, w.Close())
+		_, err = w.Write([]byte("closed"))
+		assert.Equal(t, os.ErrClosed, err)
+	})
+
+	t.Run("unixgram", func(t *testing.T) {
+		dir, cleanup := tempDir(t)
+		defer cleanup()
+		path := filepath.Join(dir, "log.sock")
+		conn, err := net.ListenPacket("unixgram", path)
+		if err != nil {
+			t.Skip("unixgram sockets are not supported:", err)
+		}
+		defer conn.Close()
+
+		sockets := syslogSockets
+		syslogSockets = []string{path}
+		defer func() {
+			syslogSockets = sockets
+		}()
+		w, err := DialSyslog("", "")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer w.Close()
+
+		New().SetOutput(w).SetFormatter(formatter).Prefix("local").Debug("over unixgram")
+		buf := make([]byte, 1024)
+		_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		assert.NoError(t, err)
+		msg := string(buf[:n])
+		assert.True(t, strings.HasPrefix(msg, "<15>1 "), msg)
+		assert.True(t, strings.HasSuffix(msg, " host local "+strconv.Itoa(os.Getpid())+" - - over unixgram"), msg)
+	})
+
+	t.Run("tcp timeout", func(t *testing.T) {
+		timeout := syslogTimeout
+		syslogTimeout = 50 * time.Millisecond
+		defer func() {
+			syslogTimeout = timeout
+		}()
+		// The server never reads, so writes block once the buffers are full.
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer listener.Close()
+		w, err := DialSyslog("tcp", listener.Addr().String())
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			msg := bytes.Repeat([]byte("a"), 1<<20)
+			for i := 0; i < 20; i++ {
+				_, _ = w.Write(msg)
+			}
+		}()
+		select {
+		case <-done:
+			assert.NoError(t, w.Close())
+		case <-time.After(10 * time.Second):
+			t.Fatal("writing to a server that is not reading should time out")
+		}
+	})
+}
@@ -0,0 +1,67 @@
+package timber
+
+import (
+	"os"
+	"sync"
+)
+
+type terminalAction int
+
+const (
+	terminalAction_None terminalAction = iota
+
+	// terminalAction_Exit will sync all of the sinks and then call the exit
+	// function once the entry has been written.
+	terminalAction_Exit
+
+	// terminalAction_Panic will panic with the message once the entry has been
+	// written, but only if panics have been enabled.
+	terminalAction_Panic
+)
+
+var (
+	exitFunc      = os.Exit
+	panicEnabled  = false
+	terminateLock sync.RWMutex
+)
+
+// SetExitFunc will change the function that is called after a Fatal entry has
+// been written. By default this is os.Exit, but it can be replaced so that
+// tests can assert that a Fatal entry was written without the test process
+// exiting. If the function is nil then os.Exit is restored.
+func SetExitFunc(fn func(code int)) {
+	if fn == nil {
+		fn = os.Exit
+	}
+	terminateLock.Lock()
+	defer terminateLock.Unlock()
+	exitFunc = fn
+}
+
+// SetPanicEnabled will change whether or not Critical entries will panic once
+// they have been written. Panics are disabled by default. Critical entries that
+// are below the level of the logger are not written, so they do not panic
+// either. The value that is passed to panic is the message of the entry.
+func SetPanicEnabled(enabled bool) {
+	terminateLock.Lock()
+	defer terminateLock.Unlock()
+	panicEnabled = enabled
+}
+
+// terminate will perform the terminal action of the level provided if it has
+// one. It is called for Fatal entries even if they were not written, but only
+// for Critical entries that were.
+func terminate(lvl Level, msg string) {
+	terminateLock.RLock()
+	exit, panics := exitFunc, panicEnabled
+	terminateLock.RUnlock()
+	switch terminalActions[lvl] {
+	case terminalAction_Exit:
+		_ = Sync()
+		exit(1)
+	case terminalAction_Panic:
+		if panics {
+			panic(msg)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package timber
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// expectExit will replace the exit function so that a test can write a Fatal
+// entry without the process exiting. The function returned will restore the
+// exit function and assert that it was called with a status of 1.
+func expectExit(t *testing.T) func() {
+	code := -1
+	SetExitFunc(func(c int) {
+		code = c
+	})
+	return func() {
+		SetExitFunc(nil)
+		assert.Equal(t, 1, code, "exit function was not called")
+	}
+}
+
+type syncBuffer struct {
+	bytes.Buffer
+	synced int
+}
+
+func (s *syncBuffer) Sync() error {
+	s.synced++
+	return nil
+}
+
+func TestFatalExit(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("syncs sinks", func(t *testing.T) {
+		defer expectExit(t)()
+		buf := &syncBuffer{}
+		New().SetOutput(buf).Fatalf("test %d", 1)
+		assert.Contains(t, buf.String(), "test 1")
+		assert.Equal(t, 1, buf.synced)
+	})
+
+	t.Run("below level", func(t *testing.T) {
+		defer expectExit(t)()
+		defer SetLevel(Level_Trace)
+		SetLevel(Level_Fatal + 1)
+		buf := &syncBuffer{}
+		New().SetOutput(buf).Fatal("test")
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestSetPanicEnabled(t *testing.T) {
+	SetLevel(Level_Trace)
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			New().SetOutput(&bytes.Buffer{}).Critical("test")
+		})
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		SetPanicEnabled(true)
+		defer SetPanicEnabled(false)
+		buf := &bytes.Buffer{}
+		assert.PanicsWithValue(t, "test 1", func() {
+			New().SetOutput(buf).Criticalf("test %d", 1)
+		})
+		assert.Contains(t, buf.String(), "test 1")
+		assert.NotPanics(t, func() {
+			New().SetOutput(buf).Error("test")
+		})
+	})
+
+	t.Run("below level", func(t *testing.T) {
+		SetPanicEnabled(true)
+		defer SetPanicEnabled(false)
+		buf := &bytes.Buffer{}
+		log := New().SetOutput(buf).SetLevel(Level_Fatal)
+		assert.NotPanics(t, func() {
+			log.Critical("test")
+			log.Criticalf("test %d", 1)
+			CriticalCtx(NewContext(context.Background(), log), "test")
+		})
+		assert.Empty(t, buf.String())
+	})
+}
@@ -0,0 +1,133 @@
+package timber
+
+import (
+	"bytes"
+	"github.com/logrusorgru/aurora"
+)
+
+// TextFormatter renders entries as lines meant to be read in a console. The
+// time is written first, followed by the level, the prefix, the caller and its
+// function, any keys and then finally the message. The line is colored if the
+// entry allows it.
+type TextFormatter struct {
+	// SortKeys will write the keys of each entry in alphabetical order instead
+	// of the order that they are in the entry.
+	SortKeys bool
+
+	// TimeLayout is the layout used to write the time of each entry. It can be
+	// one of the TimeLayout values or any layout accepted by time.Format. If it
+	// is blank then DefaultTextTimeLayout is used.
+	TimeLayout string
+
+	// UTC will write the time of each entry in UTC instead of local time.
+	UTC bool
+
+	// DisableTime will stop the time of each entry from being written.
+	DisableTime bool
+}
+
+// DefaultTextTimeLayout is the layout used by a TextFormatter when one is not
+// specified.
+const DefaultTextTimeLayout = "2006-01-02 15:04:05.000"
+
+// Format will write the entry to the buffer as a single line.
+func (f *TextFormatter) Format(buf *bytes.Buffer, entry *Entry) error {
+	if !f.DisableTime {
+		layout := f.TimeLayout
+		if len(layout) == 0 {
+			layout = DefaultTextTimeLayout
+		}
+		var tmp [64]byte
+		startColor(buf, entry.Color, aurora.BrightBlack)
+		buf.Write(appendTime(tmp[:0], entry.Time, layout, f.UTC))
+		endColor(buf, entry.Color, aurora.BrightBlack)
+		buf.WriteByte(' ')
+	}
+
+	levelColor := foregroundColors[entry.Level]
+	if backgroundColor, ok := backgroundColors[entry.Level]; ok {
+		levelColor = backgroundColor
+	}
+	startColor(buf, entry.Color, levelColor)
+	buf.WriteByte('[')
+	buf.WriteString(shortLevelNames[entry.Level])
+	buf.WriteByte(']')
+	endColor(buf, entry.Color, levelColor)
+
+	if len(entry.Prefix) > 0 {
+		buf.WriteByte(' ')
+		startColor(buf, entry.Color, aurora.White)
+		buf.WriteByte('[')
+		buf.WriteString(entry.Prefix)
+		buf.WriteByte(']')
+		endColor(buf, entry.Color, aurora.White)
+	}
+
+	if len(entry.Caller) > 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(entry.Caller)
+	}
+	if len(entry.Function) > 0 {
+		buf.WriteByte(' ')
+		buf.WriteString(entry.Function)
+	}
+
+	if len(entry.Fields) > 0 {
+		buf.WriteByte(' ')
+		f.writeFields(buf, entry.Color, entry.Fields)
+		buf.WriteByte(' ')
+		startColor(buf, entry.Color, aurora.BrightBlack)
+		buf.WriteByte('|')
+		endColor(buf, entry.Color, aurora.BrightBlack)
+	}
+
+	buf.WriteByte(' ')
+	buf.WriteString(entry.Message)
+	buf.WriteByte('\n')
+	writeTextErrorStacks(buf, entry.Fields)
+	writeTextStack(buf, entry.Stack)
+	return nil
+}
+
+func (f *TextFormatter) writeFields(buf *bytes.Buffer, color bool, fields []Field) {
+	if f.SortKeys {
+		fields = append([]Field{}, fields...)
+		sortFields(fields)
+	}
+	startColor(buf, color, aurora.BrightBlack)
+	buf.WriteString("{ ")
+	endColor(buf, color, aurora.BrightBlack)
+	for i, field := range fields {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(field.Key)
+		buf.WriteString(": ")
+		startColor(buf, color, aurora.White)
+		writeTextField(buf, field)
+		endColor(buf, color, aurora.White)
+	}
+	startColor(buf, color, aurora.BrightBlack)
+	buf.WriteString(" }")
+	endColor(buf, color, aurora.BrightBlack)
+}
+
+// startColor will write the ANSI escape code that starts the color provided if
+// colors are enabled.
+func startColor(buf *bytes.Buffer, enabled bool, color colorFunc) {
+	if !enabled || color == nil {
+		return
+	}
+	buf.WriteString("\033[")
+	buf.WriteString(color(nil).Color().Nos(false))
+	buf.WriteByte('m')
+}
+
+// endColor will write the ANSI escape code that resets the color provided if
+// colors are enabled.
+func endColor(buf *bytes.Buffer, enabled bool, color colorFunc) {
+	if !enabled || color == nil {
+		return
+	}
+	buf.WriteString("\033[0m")
+}
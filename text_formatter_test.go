@@ -0,0 +1,57 @@
+package timber
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestTextFormatter_Format(t *testing.T) {
+	entry := &Entry{
+		Time:   time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+		Level:  Level_Warning,
+		Caller: "file.go:12",
+		Prefix: "prefix",
+		Fields: []Field{
+			String("things", "stuff"),
+			Int("id", 1),
+		},
+		Message: "test",
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&TextFormatter{UTC: true}).Format(buf, entry))
+		assert.Equal(t, "2019-05-01 12:00:00.000 [WARN] [prefix] file.go:12 { things: stuff, id: 1 } | test\n", buf.String())
+	})
+
+	t.Run("sorted keys", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&TextFormatter{SortKeys: true, DisableTime: true}).Format(buf, entry))
+		assert.Equal(t, "[WARN] [prefix] file.go:12 { id: 1, things: stuff } | test\n", buf.String())
+		assert.Equal(t, "things", entry.Fields[0].Key)
+	})
+
+	t.Run("no keys", func(t *testing.T) {
+		buf := bytes.NewBuffer(nil)
+		assert.NoError(t, (&TextFormatter{DisableTime: true}).Format(buf, &Entry{
+			Level:   Level_Info,
+			Caller:  "file.go:12",
+			Message: "test",
+		}))
+		assert.Equal(t, "[INFO] file.go:12 test\n", buf.String())
+	})
+
+	t.Run("time layouts", func(t *testing.T) {
+		for layout, expected := range map[string]string{
+			TimeLayout_RFC3339Nano: "2019-05-01T12:00:00Z",
+			TimeLayout_UnixMillis:  "1556712000000",
+			time.Kitchen:           "12:00PM",
+		} {
+			buf := bytes.NewBuffer(nil)
+			assert.NoError(t, (&TextFormatter{TimeLayout: layout, UTC: true}).Format(buf, entry))
+			assert.Equal(t, expected+" [WARN]", buf.String()[:len(expected)+7], layout)
+		}
+	})
+}
@@ -1,21 +1,28 @@
 package timber
 
 import (
-	"bytes"
+	"context"
 	"fmt"
-	"github.com/logrusorgru/aurora"
+	"io"
 	"os"
+	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 const (
 	defaultStackDepth = 3
+
+	// levelEnv is the environment variable that can be used to set the global
+	// level when the program starts, it accepts anything that ParseLevel does.
+	levelEnv = "TIMBER_LEVEL"
 )
 
 var (
-	level     = Level(0)
-	levelSync sync.RWMutex
+	// level is the global minimum level, it is only accessed atomically so that
+	// checking whether an entry should be written never takes a lock.
+	level int32
 )
 
 var (
@@ -25,54 +32,119 @@ var (
 func init() {
 	defaultLogger = &logger{
 		stackDepth: defaultStackDepth,
-		keys:       make(Keys),
 	}
-	if travis := os.Getenv("TRAVIS"); len(travis) > 0 {
-		SetLevel(Level_Error)
-	} else {
-		SetLevel(Level_Trace)
+	SetLevel(getEnvLevel())
+}
+
+// getEnvLevel will return the level set by the TIMBER_LEVEL environment
+// variable. If the variable is not set or is not a valid level then Level_Trace
+// is returned.
+func getEnvLevel() Level {
+	env := os.Getenv(levelEnv)
+	if len(env) == 0 {
+		return Level_Trace
+	}
+	lvl, err := ParseLevel(env)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "timber: ignoring %s: %v\n", levelEnv, err)
+		return Level_Trace
 	}
+	return lvl
 }
 
 func New() Logger {
 	return &logger{
 		stackDepth: defaultStackDepth,
-		keys:       make(Keys),
 	}
 }
 
-func shouldLog(lvl Level) bool {
-	levelSync.RLock()
-	defer levelSync.RUnlock()
-	return lvl >= level
-}
-
 type logger struct {
+	// parent is the logger that this logger was created from via With. Any
+	// settings that have not been changed on this logger are inherited from its
+	// parent, or from the global settings if it does not have a parent.
+	parent *logger
+
+	// name is the dotted name of the logger if it was created via Named.
+	name string
+
 	stackDepth int
-	keys       Keys
-	keysLock   sync.RWMutex
+	fields     []Field
+	fieldsLock sync.RWMutex
+
+	// level is accessed atomically, if it is 0 then the level is inherited.
+	level int32
 
 	prefix     string
 	prefixLock sync.RWMutex
+
+	output     io.Writer
+	outputLock sync.RWMutex
+
+	// releasesOutput is true once a finalizer has been set that will release
+	// the output of the logger when it is garbage collected.
+	releasesOutput bool
+
+	formatter     Formatter
+	formatterLock sync.RWMutex
+
+	color     colorMode
+	colorLock sync.RWMutex
+
+	stack     stackSettings
+	stackLock sync.RWMutex
+
+	caller     callerSettings
+	callerLock sync.RWMutex
+
+	// handler receives the entries of this logger instead of them being
+	// formatted and written to the output. It is only set when the logger is
+	// created and is never changed, so it does not need a lock.
+	handler entryHandler
 }
 
-func (l *logger) getKeysString(keys Keys) string {
-	l.keysLock.RLock()
-	defer l.keysLock.RUnlock()
-	msg := make([]string, 0)
-	for _, keySet := range append([]Keys{}, keys, l.keys) {
-		for k, v := range keySet {
-			// Exclude items where the value is null.
-			if v == nil {
-				continue
-			}
-			msg = append(msg, fmt.Sprintf(`%s: %v`, k, aurora.White(v)))
+// entryHandler is implemented by loggers that hand their entries off to another
+// logging library instead of writing them.
+type entryHandler interface {
+	// enabled will return true if entries of the level provided should be
+	// built, it is only called once the level of the logger has been checked.
+	enabled(lvl Level) bool
+
+	// handle will receive each entry along with the program counter of the
+	// code that wrote it. Entries are reused once handle returns.
+	handle(pc uintptr, entry *Entry)
+}
+
+// appendFields will merge the keys and fields provided at the call site with
+// the fields of the logger and append them to the fields provided. The fields
+// from the call site are first followed by the keys from the call site sorted by
+// their key, and then the fields of the logger in the order they were added.
+// When a key is present in both then the value from the call site is used.
+// Valuers are resolved here, and fields with a nil value are excluded.
+func (l *logger) appendFields(fields []Field, keys Keys, callSite []Field) []Field {
+	start := len(fields)
+	fields = append(fields, callSite...)
+	fields = appendKeys(fields, keys)
+	callSiteEnd := len(fields)
+	l.fieldsLock.RLock()
+	for _, field := range l.fields {
+		if indexOfField(fields[start:callSiteEnd], field.Key) >= 0 {
+			continue
 		}
+		fields = append(fields, field)
 	}
-	if len(msg) == 0 {
-		return ""
+	l.fieldsLock.RUnlock()
+
+	// Now that the fields of the logger have been overridden, resolve any lazy
+	// values and remove the fields where the value is null.
+	n := start
+	for _, field := range fields[start:] {
+		if field = field.resolve(); field.isNil() {
+			continue
+		}
+		fields[n] = field
+		n++
 	}
-	return fmt.Sprint(aurora.BrightBlack("{ "), strings.Join(msg, ", "), aurora.BrightBlack(" }"))
+	return fields[:n]
 }
 
 func (l *logger) getPrefixString() string {
@@ -81,39 +153,126 @@ func (l *logger) getPrefixString() string {
 	return l.prefix
 }
 
-func (l *logger) log(stack int, lvl Level, m Keys, v ...interface{}) {
+func (l *logger) getOutput() io.Writer {
+	for lg := l; lg != nil; lg = lg.parent {
+		lg.outputLock.RLock()
+		w := lg.output
+		lg.outputLock.RUnlock()
+		if w != nil {
+			return w
+		}
+	}
+	return GetOutput()
+}
+
+func (l *logger) getFormatter() Formatter {
+	for lg := l; lg != nil; lg = lg.parent {
+		lg.formatterLock.RLock()
+		f := lg.formatter
+		lg.formatterLock.RUnlock()
+		if f != nil {
+			return f
+		}
+	}
+	return GetFormatter()
+}
+
+// shouldLog will return true if the level provided is at or above the minimum
+// level of this logger. This only performs atomic loads so that disabled levels
+// are as cheap as possible.
+func (l *logger) shouldLog(lvl Level) bool {
+	if lvl < l.Level() {
+		return false
+	}
+	if h := l.getHandler(); h != nil {
+		return h.enabled(lvl)
+	}
+	return true
+}
+
+func (l *logger) getHandler() entryHandler {
+	for lg := l; lg != nil; lg = lg.parent {
+		if lg.handler != nil {
+			return lg.handler
+		}
+	}
+	return nil
+}
+
+func (l *logger) log(stack int, lvl Level, keys Keys, fields []Field, v ...interface{}) {
+	l.logContext(nil, stack+1, lvl, keys, fields, v)
+}
+
+// logContext will write an entry the same way that log does, if a context is
+// provided then the registered context hooks are called once the entry has
+// been written.
+func (l *logger) logContext(ctx context.Context, stack int, lvl Level, keys Keys, fields []Field, v []interface{}) {
+	action := terminalActions[lvl]
 	// If the message is below our level threshold then do not write it to
 	// stdout.
-	if !shouldLog(lvl) {
+	if !l.shouldLog(lvl) {
+		// Fatal entries always exit, but panics only happen for entries that
+		// were written.
+		if action == terminalAction_Exit {
+			terminate(lvl, getMessage(v))
+		}
 		return
 	}
-	k := l.getKeysString(m)
-	p := l.getPrefixString()
-	foregroundColor, ok := foregroundColors[lvl]
-	var prefix interface{}
-	s := fmt.Sprintf("[%s]", shortLevelNames[lvl])
-	if ok {
-		prefix = foregroundColor(s)
+	entry := getEntry()
+	defer putEntry(entry)
+	entry.Level = lvl
+	entry.Time = now()
+	if settings := l.getCallerSettings(); settings.mode != CallerMode_Off {
+		entry.Caller, entry.Function = callerInfo(stack, settings)
+	}
+	if settings := l.getStackSettings(); settings.mode > StackMode_Off && lvl >= settings.level {
+		entry.Stack = captureStack(stack, settings.mode == StackMode_All)
+	}
+	entry.Fields = l.appendFields(entry.Fields, keys, fields)
+	entry.Message = getMessage(v)
+	if h := l.getHandler(); h != nil {
+		var pcs [1]uintptr
+		runtime.Callers(stack, pcs[:])
+		entry.Name = l.name
+		entry.Prefix = l.getPrefixString()
+		h.handle(pcs[0], entry)
 	} else {
-		prefix = s
+		l.writeEntry(entry)
 	}
-	backgroundColor, ok := backgroundColors[lvl]
-	if ok {
-		prefix = backgroundColor(s)
+	if ctx != nil {
+		runContextHooks(ctx, entry)
 	}
-	items := []string{
-		fmt.Sprint(prefix),
+	if action != terminalAction_None {
+		terminate(lvl, entry.Message)
 	}
-	if len(p) > 0 {
-		items = append(items, fmt.Sprint(aurora.White(fmt.Sprintf("[%s]", p))))
+}
+
+// writeEntry will fill in the name, prefix and color of the entry, then format
+// it and write it to the output of the logger.
+func (l *logger) writeEntry(entry *Entry) {
+	output := l.getOutput()
+	entry.Name = l.name
+	entry.Prefix = l.getPrefixString()
+	entry.Color = l.useColor(output)
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if err := l.getFormatter().Format(buf, entry); err != nil {
+		fmt.Fprintf(os.Stderr, "timber: failed to format entry: %v\n", err)
+		return
 	}
-	items = append(items, CallerInfo(stack))
-	if len(k) > 0 {
-		items = append(items, k, fmt.Sprint(aurora.BrightBlack("|")))
+	write(output, entry.Level, buf.Bytes())
+}
+
+// getMessage will convert the values provided into the message of an entry
+// without any trailing newline.
+func getMessage(v []interface{}) string {
+	if len(v) == 1 {
+		if s, ok := v[0].(string); ok {
+			return strings.TrimSuffix(s, "\n")
+		}
 	}
-	items = append(items, fmt.Sprint(v...))
-	msg := string(bytes.TrimSuffix([]byte(strings.Join(items, " ")), []byte{'\n'}))
-	fmt.Println(msg)
+	return strings.TrimSuffix(fmt.Sprint(v...), "\n")
 }
 
 // SetDepth will change the number of stacks that will be skipped to find
@@ -126,7 +285,7 @@ func (l *logger) SetDepth(depth int) Logger {
 // Log will write a raw entry to the log, it accepts an array of interfaces which will
 // be converted to strings if they are not already.
 func (l *logger) Log(lvl Level, v ...interface{}) {
-	l.log(l.stackDepth, lvl, nil, v...)
+	l.log(l.stackDepth, lvl, nil, nil, v...)
 }
 
 // With will create a new Logger interface that will prefix all log entries written
@@ -135,12 +294,16 @@ func (l *logger) Log(lvl Level, v ...interface{}) {
 // This means that you can chain multiple of these together to add/remove keys that
 // are written with every message.
 func (l *logger) With(keys Keys) Logger {
+	return l.WithFields(keysToFields(keys)...)
+}
+
+// WithFields will create a new Logger interface the same way that With does, but
+// with typed fields instead of keys.
+func (l *logger) WithFields(fields ...Field) Logger {
 	lg := l.Clone()
-	lg.keysLock.Lock()
-	defer lg.keysLock.Unlock()
-	for k, v := range keys {
-		lg.keys[k] = v
-	}
+	lg.fieldsLock.Lock()
+	defer lg.fieldsLock.Unlock()
+	lg.fields = setFields(lg.fields, fields...)
 	return lg
 }
 
@@ -152,45 +315,100 @@ func (l *logger) Prefix(prefix string) Logger {
 	return l
 }
 
+// SetOutput will change the sink that this logger and any loggers created from
+// it via With will write to. If the output is nil then the logger will write to
+// the global output instead.
+func (l *logger) SetOutput(w io.Writer) Logger {
+	addSink(w)
+	l.outputLock.Lock()
+	previous := l.output
+	l.output = w
+	if w != nil && !l.releasesOutput {
+		// Stop syncing the output once the logger is garbage collected.
+		l.releasesOutput = true
+		runtime.SetFinalizer(l, (*logger).releaseOutput)
+	}
+	l.outputLock.Unlock()
+	removeSink(previous)
+	return l
+}
+
+// releaseOutput will stop the output of the logger from being synced, it is
+// called once the logger has been garbage collected.
+func (l *logger) releaseOutput() {
+	l.outputLock.Lock()
+	defer l.outputLock.Unlock()
+	removeSink(l.output)
+	l.output = nil
+}
+
+// SetFormatter will change how entries are rendered by this logger and any
+// loggers created from it via With. If the formatter is nil then the logger
+// will use the global formatter instead.
+func (l *logger) SetFormatter(f Formatter) Logger {
+	l.formatterLock.Lock()
+	defer l.formatterLock.Unlock()
+	l.formatter = f
+	return l
+}
+
+// SetLevel will set the minimum message level that will be written by this
+// logger and any loggers created from it via With, overriding the global level.
+// Setting the level to 0 will make the logger inherit its level again.
+func (l *logger) SetLevel(lvl Level) Logger {
+	atomic.StoreInt32(&l.level, int32(lvl))
+	return l
+}
+
+// Level will return the minimum message level that will be written by this
+// logger. If the level has not been set on this logger or any of the loggers it
+// was created from then this is the global level.
+func (l *logger) Level() Level {
+	for lg := l; lg != nil; lg = lg.parent {
+		if lvl := atomic.LoadInt32(&lg.level); lvl != 0 {
+			return Level(lvl)
+		}
+	}
+	return GetLevel()
+}
+
 func (l *logger) Clone() *logger {
-	l.keysLock.Lock()
-	defer l.keysLock.Unlock()
-	lg := &logger{
+	l.fieldsLock.RLock()
+	defer l.fieldsLock.RUnlock()
+	return &logger{
+		parent:     l,
+		name:       l.name,
 		stackDepth: l.stackDepth,
-		keys:       map[string]interface{}{},
+		fields:     append([]Field{}, l.fields...),
 		prefix:     l.getPrefixString(),
 	}
-	lg.keysLock.Lock()
-	defer lg.keysLock.Unlock()
-	for k, v := range l.keys {
-		lg.keys[k] = v
-	}
-	return lg
 }
 
 func With(keys Keys) Logger {
 	return defaultLogger.With(keys)
 }
 
-// SetLevel will set the minimum message level that will be output to stdout.
-// This level is inherited by new logging instances created via With. But does
-// not affect completely new logging instances.
+// WithFields will create a new Logger interface from the global logger the same
+// way that With does, but with typed fields instead of keys.
+func WithFields(fields ...Field) Logger {
+	return defaultLogger.WithFields(fields...)
+}
+
+// SetLevel will set the minimum message level that will be written by the
+// global logger. This level is also used by every other logger that has not had
+// its own level set via Logger.SetLevel.
 func SetLevel(lvl Level) {
-	levelSync.Lock()
-	defer levelSync.Unlock()
-	level = lvl
+	atomic.StoreInt32(&level, int32(lvl))
 }
 
 // GetLevel will return the current minimum logging level for the global
 // logger.
 func GetLevel() Level {
-	levelSync.RLock()
-	defer levelSync.RUnlock()
-	return level
+	return Level(atomic.LoadInt32(&level))
 }
 
 // Log will write a raw entry to the log, it accepts an array of interfaces which will
 // be converted to strings if they are not already.
 func Log(lvl Level, v ...interface{}) {
-	defaultLogger.Log(lvl, v...)
+	defaultLogger.log(defaultLogger.stackDepth, lvl, nil, nil, v...)
 }
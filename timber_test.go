@@ -1,8 +1,10 @@
 package timber
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"os"
 	"sync"
 	"testing"
 )
@@ -78,6 +80,42 @@ func TestGetLevel(t *testing.T) {
 	assert.NotEqual(t, firstLevel, finalLevel)
 }
 
+func TestParseLevel(t *testing.T) {
+	t.Run("mixed case", func(t *testing.T) {
+		lvl, err := ParseLevel(" WaRn ")
+		assert.NoError(t, err)
+		assert.Equal(t, Level_Warning, lvl)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, s := range []string{"", "loud", "9", "-1"} {
+			_, err := ParseLevel(s)
+			assert.Error(t, err, s)
+		}
+	})
+
+	t.Run("unknown level text", func(t *testing.T) {
+		assert.Equal(t, "Level(42)", Level(42).String())
+		text, err := Level(42).MarshalText()
+		assert.NoError(t, err)
+		assert.Equal(t, "42", string(text))
+		assert.Error(t, new(Level).UnmarshalText(text))
+	})
+}
+
+func TestGetEnvLevel(t *testing.T) {
+	defer os.Unsetenv(levelEnv)
+
+	os.Unsetenv(levelEnv)
+	assert.Equal(t, Level_Trace, getEnvLevel())
+
+	os.Setenv(levelEnv, "warning")
+	assert.Equal(t, Level_Warning, getEnvLevel())
+
+	os.Setenv(levelEnv, "loud")
+	assert.Equal(t, Level_Trace, getEnvLevel())
+}
+
 func TestConcurrent(t *testing.T) {
 	t.Run("normal", func(t *testing.T) {
 		log := New()
@@ -210,3 +248,44 @@ func TestConcurrent(t *testing.T) {
 		done.doneSync.Unlock()
 	})
 }
+
+func TestLogger_SetLevel(t *testing.T) {
+	SetLevel(Level_Debug)
+	defer SetLevel(Level_Trace)
+
+	buf := bytes.NewBuffer(nil)
+	noisy := New().SetOutput(buf)
+	child := noisy.With(Keys{"subsystem": "noisy"})
+	noisy.SetLevel(Level_Warning)
+	assert.Equal(t, Level_Warning, noisy.Level())
+	assert.Equal(t, Level_Warning, child.Level())
+	assert.Equal(t, Level_Debug, New().Level())
+
+	child.Info("child info")
+	noisy.Debug("noisy debug")
+	noisy.Warning("noisy warning")
+	assert.NotContains(t, buf.String(), "child info")
+	assert.NotContains(t, buf.String(), "noisy debug")
+	assert.Contains(t, buf.String(), "noisy warning")
+
+	child.SetLevel(Level_Info)
+	child.Info("child info")
+	assert.Contains(t, buf.String(), "child info")
+	assert.Equal(t, Level_Warning, noisy.Level())
+
+	noisy.SetLevel(0)
+	child.SetLevel(0)
+	assert.Equal(t, Level_Debug, child.Level())
+}
+
+func TestLogger_WithInheritance(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+	log := New().SetOutput(buf).SetLevel(Level_Trace).With(Keys{"a": 1})
+	child := log.With(Keys{"b": 2})
+	log.SetLevel(Level_Error)
+	assert.Equal(t, Level_Error, child.Level())
+	child.Info("skipped")
+	child.Error("written")
+	assert.NotContains(t, buf.String(), "skipped")
+	assert.Contains(t, buf.String(), "written")
+}
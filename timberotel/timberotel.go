@@ -0,0 +1,127 @@
+// Package timberotel correlates timber entries with OpenTelemetry traces. When
+// a span is active in the context given to the *Ctx methods its trace and span
+// IDs are added to the entry, and entries can optionally be recorded as events
+// on the span so that a trace can be followed to its logs and back.
+//
+//	timberotel.Register(timberotel.Options{EventLevel: timber.Level_Error})
+//	...
+//	ctx, span := tracer.Start(ctx, "load")
+//	defer span.End()
+//	log.ErrorCtx(ctx, "failed to load %s", id)
+package timberotel
+
+import (
+	"context"
+	"fmt"
+	"github.com/elliotcourant/timber"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"strconv"
+	"time"
+)
+
+const (
+	// TraceIDKey is the key of the field that the trace ID is written to.
+	TraceIDKey = "trace_id"
+
+	// SpanIDKey is the key of the field that the span ID is written to.
+	SpanIDKey = "span_id"
+
+	// EventName is the name of the events that entries are recorded as.
+	EventName = "log"
+
+	// SeverityKey is the attribute of an event that stores the level of the
+	// entry.
+	SeverityKey = "log.severity"
+
+	// MessageKey is the attribute of an event that stores the message of the
+	// entry.
+	MessageKey = "log.message"
+)
+
+// Options changes what is added to entries and spans.
+type Options struct {
+	// EventLevel is the minimum level of the entries that are recorded as
+	// events on the active span, like timber.Level_Error. If it is 0 then no
+	// events are recorded.
+	EventLevel timber.Level
+}
+
+// Register will add the trace and span IDs of the active span to every entry
+// written via the *Ctx methods. If an event level is provided then entries at or
+// above that level are also recorded as events on the span.
+func Register(options Options) {
+	timber.RegisterContextExtractor(Extractor)
+	if options.EventLevel != 0 {
+		timber.RegisterContextHook(SpanEvents(options.EventLevel))
+	}
+}
+
+// Extractor is a timber.ContextExtractor that adds the trace and span IDs of
+// the span in the context as the trace_id and span_id fields. Nothing is added
+// if the context does not have a valid span.
+func Extractor(ctx context.Context, fields []timber.Field) []timber.Field {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return fields
+	}
+	return append(fields,
+		timber.String(TraceIDKey, spanContext.TraceID().String()),
+		timber.String(SpanIDKey, spanContext.SpanID().String()),
+	)
+}
+
+// SpanEvents will create a timber.ContextHook that records every entry at or
+// above the level provided as an event on the span in the context. The message
+// and level are stored in the log.message and log.severity attributes, and each
+// field of the entry is added as an attribute of its own. Spans that are not
+// being recorded are ignored.
+func SpanEvents(lvl timber.Level) timber.ContextHook {
+	return func(ctx context.Context, entry *timber.Entry) {
+		if entry.Level < lvl {
+			return
+		}
+		span := trace.SpanFromContext(ctx)
+		if !span.IsRecording() {
+			return
+		}
+		attributes := make([]attribute.KeyValue, 0, len(entry.Fields)+2)
+		attributes = append(attributes,
+			attribute.String(SeverityKey, entry.Level.String()),
+			attribute.String(MessageKey, entry.Message),
+		)
+		for _, field := range entry.Fields {
+			if field.Key == TraceIDKey || field.Key == SpanIDKey {
+				continue
+			}
+			attributes = append(attributes, fieldToAttribute(field))
+		}
+		span.AddEvent(EventName, trace.WithTimestamp(entry.Time), trace.WithAttributes(attributes...))
+	}
+}
+
+// fieldToAttribute will convert a field into a span attribute. Values that do
+// not have an attribute type of their own are stored as strings.
+func fieldToAttribute(field timber.Field) attribute.KeyValue {
+	switch field.Type {
+	case timber.FieldType_String:
+		return attribute.String(field.Key, field.String)
+	case timber.FieldType_Int:
+		return attribute.Int64(field.Key, field.Integer)
+	case timber.FieldType_Uint:
+		if field.Integer >= 0 {
+			return attribute.Int64(field.Key, field.Integer)
+		}
+		return attribute.String(field.Key, strconv.FormatUint(uint64(field.Integer), 10))
+	}
+	switch value := field.Interface().(type) {
+	case bool:
+		return attribute.Bool(field.Key, value)
+	case float64:
+		return attribute.Float64(field.Key, value)
+	case time.Time:
+		return attribute.String(field.Key, value.Format(time.RFC3339Nano))
+	default:
+		return attribute.String(field.Key, fmt.Sprint(value))
+	}
+}
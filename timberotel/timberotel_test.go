@@ -0,0 +1,95 @@
+package timberotel
+
+import (
+	"context"
+	"errors"
+	"github.com/elliotcourant/timber"
+	"github.com/elliotcourant/timber/timbertest"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"testing"
+	"time"
+)
+
+func TestRegister(t *testing.T) {
+	timber.SetLevel(timber.Level_Trace)
+	Register(Options{EventLevel: timber.Level_Error})
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	defer provider.Shutdown(context.Background())
+
+	log, recorder := timbertest.New()
+	log = log.With(timber.Keys{"service": "api"})
+
+	ctx, span := provider.Tracer("timberotel").Start(context.Background(), "load")
+	log.InfoCtx(ctx, "loading")
+	log.ErrorCtx(ctx, "failed to load %d", 1)
+	log.Errorw("no context", timber.Err(errors.New("failed")))
+	log.CriticalCtx(context.Background(), "no span")
+	span.End()
+
+	entries := recorder.Entries()
+	if assert.Len(t, entries, 4) {
+		traceID, spanID := span.SpanContext().TraceID().String(), span.SpanContext().SpanID().String()
+		for _, entry := range entries[:2] {
+			assert.Equal(t, traceID, entry.Keys[TraceIDKey])
+			assert.Equal(t, spanID, entry.Keys[SpanIDKey])
+		}
+		for _, entry := range entries[2:] {
+			assert.NotContains(t, entry.Keys, TraceIDKey)
+			assert.NotContains(t, entry.Keys, SpanIDKey)
+		}
+	}
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) && assert.Len(t, spans[0].Events, 1) {
+		event := spans[0].Events[0]
+		assert.Equal(t, EventName, event.Name)
+		assert.Equal(t, entries[1].Time, event.Time)
+		assert.Equal(t, []attribute.KeyValue{
+			attribute.String(SeverityKey, "Error"),
+			attribute.String(MessageKey, "failed to load 1"),
+			attribute.String("service", "api"),
+		}, event.Attributes)
+	}
+}
+
+func TestExtractor(t *testing.T) {
+	fields := Extractor(context.Background(), nil)
+	assert.Empty(t, fields)
+
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background())
+	ctx, span := provider.Tracer("timberotel").Start(context.Background(), "test")
+	defer span.End()
+	fields = Extractor(ctx, []timber.Field{timber.Int("id", 1)})
+	assert.Equal(t, []timber.Field{
+		timber.Int("id", 1),
+		timber.String(TraceIDKey, span.SpanContext().TraceID().String()),
+		timber.String(SpanIDKey, span.SpanContext().SpanID().String()),
+	}, fields)
+}
+
+func TestFieldToAttribute(t *testing.T) {
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, item := range []struct {
+		field     timber.Field
+		attribute attribute.KeyValue
+	}{
+		{timber.String("a", "b"), attribute.String("a", "b")},
+		{timber.Int("a", -1), attribute.Int64("a", -1)},
+		{timber.Uint64("a", 1), attribute.Int64("a", 1)},
+		{timber.Uint64("a", 1<<63), attribute.String("a", "9223372036854775808")},
+		{timber.Float64("a", 1.5), attribute.Float64("a", 1.5)},
+		{timber.Bool("a", true), attribute.Bool("a", true)},
+		{timber.Duration("a", time.Second), attribute.String("a", "1s")},
+		{timber.Time("a", now), attribute.String("a", "2020-01-02T03:04:05Z")},
+		{timber.Err(errors.New("failed")), attribute.String("error", "failed")},
+		{timber.Any("a", []int{1, 2}), attribute.String("a", "[1 2]")},
+	} {
+		assert.Equal(t, item.attribute, fieldToAttribute(item.field), item.field.Key)
+	}
+}
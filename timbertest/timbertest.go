@@ -0,0 +1,192 @@
+// Package timbertest provides loggers for tests. A Recorder keeps every entry
+// that is written so that tests can assert what was logged, and NewTB creates a
+// logger that writes through testing.TB so that output is only shown for tests
+// that fail.
+package timbertest
+
+import (
+	"bytes"
+	"github.com/elliotcourant/timber"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Entry is a single entry that was written to a Recorder.
+type Entry struct {
+	Level    timber.Level
+	Time     time.Time
+	Caller   string
+	Function string
+	Name     string
+	Prefix   string
+	Message  string
+
+	// Fields are the fields of the entry in the order they were written.
+	Fields []timber.Field
+
+	// Keys are the fields of the entry with their values boxed, so that they
+	// can be compared to the keys that were passed to the logger.
+	Keys timber.Keys
+
+	// Stack is the stack that was captured with the entry, see timber.SetStack.
+	Stack []timber.Goroutine
+}
+
+// Recorder is a timber.Formatter that records every entry instead of writing
+// it. It is safe to use from multiple goroutines.
+type Recorder struct {
+	entries []Entry
+	lock    sync.Mutex
+}
+
+// New will create a logger that records every entry it writes in the Recorder
+// returned. Nothing is written to an output.
+//
+//	log, recorder := timbertest.New()
+//	service := NewService(log)
+//	service.Run()
+//	recorder.AssertLogged(t, timber.Level_Error, "connection refused")
+func New() (timber.Logger, *Recorder) {
+	r := &Recorder{}
+	return timber.New().SetOutput(ioutil.Discard).SetFormatter(r), r
+}
+
+// CaptureGlobal will record every entry that is written by the global logger,
+// and any other logger that uses the global output and formatter, until the
+// test finishes. The global output and formatter are restored once the test
+// and its subtests have completed.
+func CaptureGlobal(t testing.TB) *Recorder {
+	r := &Recorder{}
+	output, formatter := timber.GetOutput(), timber.GetFormatter()
+	timber.SetOutput(ioutil.Discard)
+	timber.SetFormatter(r)
+	t.Cleanup(func() {
+		timber.SetOutput(output)
+		timber.SetFormatter(formatter)
+	})
+	return r
+}
+
+// Format will record the entry.
+func (r *Recorder) Format(_ *bytes.Buffer, entry *timber.Entry) error {
+	e := Entry{
+		Level:    entry.Level,
+		Time:     entry.Time,
+		Caller:   entry.Caller,
+		Function: entry.Function,
+		Name:     entry.Name,
+		Prefix:   entry.Prefix,
+		Message:  entry.Message,
+		Fields:   append([]timber.Field{}, entry.Fields...),
+		Keys:     make(timber.Keys, len(entry.Fields)),
+		Stack:    entry.Stack,
+	}
+	for _, field := range entry.Fields {
+		e.Keys[field.Key] = field.Interface()
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = append(r.entries, e)
+	return nil
+}
+
+// Entries will return every entry that has been recorded in the order they
+// were written.
+func (r *Recorder) Entries() []Entry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]Entry{}, r.entries...)
+}
+
+// Find will return every entry of the level provided whose message contains the
+// substring provided.
+func (r *Recorder) Find(lvl timber.Level, substr string) []Entry {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	entries := make([]Entry, 0)
+	for _, entry := range r.entries {
+		if entry.Level == lvl && strings.Contains(entry.Message, substr) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Reset will remove every entry that has been recorded.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.entries = nil
+}
+
+// AssertLogged will fail the test if no entry of the level provided was
+// recorded with a message that contains the substring provided.
+func (r *Recorder) AssertLogged(t testing.TB, lvl timber.Level, substr string) bool {
+	t.Helper()
+	if len(r.Find(lvl, substr)) > 0 {
+		return true
+	}
+	t.Errorf("no entry at level %s containing %q was logged%s", lvl, substr, r.describe())
+	return false
+}
+
+// AssertNotLogged will fail the test if an entry of the level provided was
+// recorded with a message that contains the substring provided.
+func (r *Recorder) AssertNotLogged(t testing.TB, lvl timber.Level, substr string) bool {
+	t.Helper()
+	if len(r.Find(lvl, substr)) == 0 {
+		return true
+	}
+	t.Errorf("an entry at level %s containing %q was logged%s", lvl, substr, r.describe())
+	return false
+}
+
+// describe will list every entry that was recorded so that failed assertions
+// show what was actually logged.
+func (r *Recorder) describe() string {
+	entries := r.Entries()
+	if len(entries) == 0 {
+		return ", nothing was logged"
+	}
+	var b strings.Builder
+	b.WriteString(", entries:")
+	for _, entry := range entries {
+		b.WriteString("\n\t[")
+		b.WriteString(entry.Level.String())
+		b.WriteString("] ")
+		b.WriteString(entry.Message)
+	}
+	return b.String()
+}
+
+// tbWriter writes each entry through testing.TB.
+type tbWriter struct {
+	t    testing.TB
+	done bool
+	lock sync.Mutex
+}
+
+// NewTB will create a logger that writes each entry via t.Log, so that the
+// output is only shown when the test fails or when tests are run with -v.
+// Entries written after the test has finished are discarded.
+func NewTB(t testing.TB) timber.Logger {
+	w := &tbWriter{t: t}
+	t.Cleanup(func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.done = true
+	})
+	return timber.New().SetOutput(w).SetColor(false).SetFormatter(&timber.TextFormatter{DisableTime: true})
+}
+
+func (w *tbWriter) Write(p []byte) (int, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.done {
+		w.t.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
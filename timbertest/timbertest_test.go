@@ -0,0 +1,108 @@
+package timbertest
+
+import (
+	"fmt"
+	"github.com/elliotcourant/timber"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// fakeTB records the errors and logs of a test instead of failing it.
+type fakeTB struct {
+	testing.TB
+	errors   []string
+	logs     []string
+	cleanups []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...interface{}) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Log(args ...interface{}) {
+	f.logs = append(f.logs, fmt.Sprint(args...))
+}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanups = append(f.cleanups, fn)
+}
+
+func (f *fakeTB) finish() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	timber.SetLevel(timber.Level_Trace)
+	log, recorder := New()
+	log = log.Prefix("prefix").With(timber.Keys{"service": "api"})
+	log.InfoEx(timber.Keys{"id": 1}, "started %s", "worker")
+	log.Errorw("connection refused", timber.String("host", "db"))
+
+	entries := recorder.Entries()
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, timber.Level_Info, entries[0].Level)
+		assert.Equal(t, "started worker", entries[0].Message)
+		assert.Equal(t, "prefix", entries[0].Prefix)
+		assert.Contains(t, entries[0].Caller, "timbertest_test.go")
+		assert.False(t, entries[0].Time.IsZero())
+		assert.Equal(t, timber.Keys{"id": int64(1), "service": "api"}, entries[0].Keys)
+		assert.Equal(t, []timber.Field{timber.Int("id", 1), timber.String("service", "api")}, entries[0].Fields)
+		assert.Equal(t, timber.Keys{"host": "db", "service": "api"}, entries[1].Keys)
+	}
+
+	assert.True(t, recorder.AssertLogged(t, timber.Level_Error, "refused"))
+	assert.True(t, recorder.AssertNotLogged(t, timber.Level_Warning, "refused"))
+	assert.Len(t, recorder.Find(timber.Level_Info, ""), 1)
+
+	fake := &fakeTB{}
+	assert.False(t, recorder.AssertLogged(fake, timber.Level_Warning, "refused"))
+	assert.False(t, recorder.AssertNotLogged(fake, timber.Level_Error, "connection"))
+	if assert.Len(t, fake.errors, 2) {
+		assert.Equal(t, "no entry at level Warning containing \"refused\" was logged, entries:"+
+			"\n\t[Info] started worker\n\t[Error] connection refused", fake.errors[0])
+		assert.Contains(t, fake.errors[1], "an entry at level Error containing \"connection\" was logged")
+	}
+
+	recorder.Reset()
+	assert.Empty(t, recorder.Entries())
+	fake = &fakeTB{}
+	recorder.AssertLogged(fake, timber.Level_Error, "refused")
+	assert.Equal(t, []string{"no entry at level Error containing \"refused\" was logged, nothing was logged"}, fake.errors)
+}
+
+func TestCaptureGlobal(t *testing.T) {
+	timber.SetLevel(timber.Level_Trace)
+	output, formatter := timber.GetOutput(), timber.GetFormatter()
+	fake := &fakeTB{}
+	recorder := CaptureGlobal(fake)
+	timber.Warning("global")
+	timber.New().Debug("new")
+	fake.finish()
+	timber.Info("after")
+
+	recorder.AssertLogged(t, timber.Level_Warning, "global")
+	recorder.AssertLogged(t, timber.Level_Debug, "new")
+	recorder.AssertNotLogged(t, timber.Level_Info, "after")
+	assert.Equal(t, output, timber.GetOutput())
+	assert.Equal(t, formatter, timber.GetFormatter())
+}
+
+func TestNewTB(t *testing.T) {
+	timber.SetLevel(timber.Level_Trace)
+	fake := &fakeTB{}
+	log := NewTB(fake)
+	log.With(timber.Keys{"id": 1}).Warning("test")
+	fake.finish()
+	log.Info("after the test")
+
+	if assert.Len(t, fake.logs, 1) {
+		assert.Contains(t, fake.logs[0], "[WARN] ")
+		assert.Contains(t, fake.logs[0], "timbertest_test.go:")
+		assert.Contains(t, fake.logs[0], "{ id: 1 } | test")
+		assert.NotContains(t, fake.logs[0], "\n")
+	}
+}